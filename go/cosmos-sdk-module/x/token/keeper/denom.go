@@ -0,0 +1,109 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/example/token/x/token/types"
+)
+
+// GetDenomMetadata returns the metadata of a created denom
+func (k Keeper) GetDenomMetadata(ctx sdk.Context, denom string) (types.DenomMetadata, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.DenomMetadataKey(denom))
+	if bz == nil {
+		return types.DenomMetadata{}, false
+	}
+
+	var metadata types.DenomMetadata
+	types.ModuleCdc.LegacyAmino.MustUnmarshal(bz, &metadata)
+	return metadata, true
+}
+
+// SetDenomMetadata stores denom metadata and indexes its normalized symbol
+func (k Keeper) SetDenomMetadata(ctx sdk.Context, metadata types.DenomMetadata) {
+	store := ctx.KVStore(k.storeKey)
+
+	if existing, found := k.GetDenomMetadata(ctx, metadata.Denom); found {
+		store.Delete(types.SymbolIndexKey(types.NormalizeSymbol(existing.Symbol), existing.Denom))
+	}
+
+	bz := types.ModuleCdc.LegacyAmino.MustMarshal(&metadata)
+	store.Set(types.DenomMetadataKey(metadata.Denom), bz)
+	store.Set(types.SymbolIndexKey(types.NormalizeSymbol(metadata.Symbol), metadata.Denom), []byte{0x01})
+}
+
+// GetAllDenomMetadata returns the metadata of every created denom
+func (k Keeper) GetAllDenomMetadata(ctx sdk.Context) []types.DenomMetadata {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.DenomMetadataKeyPrefix)
+	defer iterator.Close()
+
+	denoms := []types.DenomMetadata{}
+	for ; iterator.Valid(); iterator.Next() {
+		var metadata types.DenomMetadata
+		types.ModuleCdc.LegacyAmino.MustUnmarshal(iterator.Value(), &metadata)
+		denoms = append(denoms, metadata)
+	}
+
+	return denoms
+}
+
+// GetDenomsBySymbol returns the denoms whose symbol normalizes to the same
+// form as the given symbol
+func (k Keeper) GetDenomsBySymbol(ctx sdk.Context, symbol string) []string {
+	store := ctx.KVStore(k.storeKey)
+	prefix := types.SymbolIndexPrefix(types.NormalizeSymbol(symbol))
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	var denoms []string
+	for ; iterator.Valid(); iterator.Next() {
+		denoms = append(denoms, string(iterator.Key()[len(prefix):]))
+	}
+
+	return denoms
+}
+
+// CreateDenom registers a new denom with its creator as admin. When the
+// DenomSymbolCollisionCheck param is enabled, it rejects a symbol that
+// normalizes to the same form as an existing denom's symbol, unless the
+// creator is the admin of that existing denom.
+func (k Keeper) CreateDenom(ctx sdk.Context, creator sdk.AccAddress, denom, symbol string) error {
+	if _, found := k.GetDenomMetadata(ctx, denom); found {
+		return sdkerrors.Wrapf(types.ErrDenomExists, "denom %s", denom)
+	}
+
+	if k.GetParams(ctx).DenomSymbolCollisionCheck {
+		for _, existingDenom := range k.GetDenomsBySymbol(ctx, symbol) {
+			existing, _ := k.GetDenomMetadata(ctx, existingDenom)
+			if existing.Admin != creator.String() {
+				return sdkerrors.Wrapf(types.ErrSymbolCollision, "symbol %q matches %q of denom %s", symbol, existing.Symbol, existing.Denom)
+			}
+		}
+	}
+
+	metadata := types.DenomMetadata{
+		Denom:  denom,
+		Symbol: symbol,
+		Admin:  creator.String(),
+	}
+	if err := metadata.Validate(); err != nil {
+		return err
+	}
+
+	k.SetDenomMetadata(ctx, metadata)
+
+	// Emit create denom event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeCreate,
+			sdk.NewAttribute(types.AttributeKeyDenom, denom),
+			sdk.NewAttribute(types.AttributeKeySymbol, symbol),
+			sdk.NewAttribute(types.AttributeKeyAdmin, creator.String()),
+		),
+	)
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/example/token/x/token/types"
+)
+
+func TestCreateDenomSymbolCollision(t *testing.T) {
+	tests := []struct {
+		name           string
+		collisionCheck bool
+		creator        sdk.AccAddress
+		symbol         string
+		err            error
+	}{
+		{
+			name:           "exact symbol",
+			collisionCheck: true,
+			creator:        bob,
+			symbol:         "USDC",
+			err:            types.ErrSymbolCollision,
+		},
+		{
+			name:           "case-folded symbol",
+			collisionCheck: true,
+			creator:        bob,
+			symbol:         "usdc",
+			err:            types.ErrSymbolCollision,
+		},
+		{
+			name:           "NFKC fullwidth symbol",
+			collisionCheck: true,
+			creator:        bob,
+			symbol:         "ＵＳＤＣ",
+			err:            types.ErrSymbolCollision,
+		},
+		{
+			name:           "Cyrillic homoglyph",
+			collisionCheck: true,
+			creator:        bob,
+			symbol:         "USDС", // Cyrillic С
+			err:            types.ErrSymbolCollision,
+		},
+		{
+			name:           "distinct symbol",
+			collisionCheck: true,
+			creator:        bob,
+			symbol:         "USDT",
+		},
+		{
+			name:           "collision check disabled",
+			collisionCheck: false,
+			creator:        bob,
+			symbol:         "USDC",
+		},
+		{
+			name:           "admin of the existing denom",
+			collisionCheck: true,
+			creator:        alice,
+			symbol:         "usdc",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k, ctx := setupKeeper(t)
+			k.SetParams(ctx, types.NewParams(tc.collisionCheck))
+			require.NoError(t, k.CreateDenom(ctx, alice, "uusdc", "USDC"))
+
+			err := k.CreateDenom(ctx, tc.creator, "unew", tc.symbol)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				_, found := k.GetDenomMetadata(ctx, "unew")
+				require.False(t, found)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, k.GetDenomsBySymbol(ctx, tc.symbol), "unew")
+		})
+	}
+}
+
+func TestCreateDenomHomoglyphLatinA(t *testing.T) {
+	k, ctx := setupKeeper(t)
+	k.SetParams(ctx, types.NewParams(true))
+	require.NoError(t, k.CreateDenom(ctx, alice, "uatom", "ATOM"))
+
+	// Cyrillic "а" (U+0430) in place of the Latin "a"
+	err := k.CreateDenom(ctx, bob, "ufake", "аtom")
+	require.ErrorIs(t, err, types.ErrSymbolCollision)
+}
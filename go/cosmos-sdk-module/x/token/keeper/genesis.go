@@ -0,0 +1,85 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/example/token/x/token/types"
+)
+
+// InitGenesis initializes the token module's state from a genesis state.
+// It panics if the balances do not match the declared supplies or violate a
+// supply constraint, so launch-config errors are caught before the chain starts.
+func (k Keeper) InitGenesis(ctx sdk.Context, genState types.GenesisState) {
+	if err := genState.Validate(); err != nil {
+		panic(err)
+	}
+
+	k.SetParams(ctx, genState.Params)
+
+	for _, metadata := range genState.Denoms {
+		k.SetDenomMetadata(ctx, metadata)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	for _, account := range genState.RegisteredAccounts {
+		store.Set(types.RegistryKey(sdk.MustAccAddressFromBech32(account)), []byte{0x01})
+	}
+
+	for _, balance := range genState.Balances {
+		addr := sdk.MustAccAddressFromBech32(balance.Address)
+		k.SetBalance(ctx, addr, balance.Denom, balance.Amount)
+	}
+}
+
+// ExportGenesis returns the token module's exported genesis state.
+// Supply constraints are launch-time checks and are not carried into the export.
+func (k Keeper) ExportGenesis(ctx sdk.Context) *types.GenesisState {
+	genesis := types.DefaultGenesis()
+	genesis.Params = k.GetParams(ctx)
+	genesis.Denoms = k.GetAllDenomMetadata(ctx)
+	genesis.RegisteredAccounts = k.GetAllRegisteredAccounts(ctx)
+
+	totals := make(map[string]sdk.Int)
+	var denoms []string
+	k.IterateAllBalances(ctx, func(balance types.Balance) bool {
+		genesis.Balances = append(genesis.Balances, balance)
+
+		total, ok := totals[balance.Denom]
+		if !ok {
+			total = sdk.ZeroInt()
+			denoms = append(denoms, balance.Denom)
+		}
+		totals[balance.Denom] = total.Add(balance.Amount)
+		return false
+	})
+
+	for _, denom := range denoms {
+		genesis.Supply = append(genesis.Supply, types.DenomSupply{
+			Denom:  denom,
+			Amount: totals[denom],
+		})
+	}
+
+	if err := genesis.Validate(); err != nil {
+		panic(err)
+	}
+
+	return genesis
+}
+
+// IterateAllBalances iterates over every stored balance, stopping early when
+// the callback returns true
+func (k Keeper) IterateAllBalances(ctx sdk.Context, cb func(balance types.Balance) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.BalanceKeyPrefix)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		amount := mustUnmarshalInt(iterator.Value())
+
+		addr, denom := types.AddressAndDenomFromBalanceKey(iterator.Key())
+		if cb(types.Balance{Address: addr.String(), Denom: denom, Amount: amount}) {
+			break
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package keeper_test
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/example/token/x/token/types"
+)
+
+// moduleAddr is a 32-byte address, the length of module and derived accounts
+var moduleAddr = sdk.AccAddress(bytes.Repeat([]byte{0x07}, 32))
+
+func TestGenesisRoundTrip(t *testing.T) {
+	k, ctx := setupKeeper(t)
+
+	genState := types.DefaultGenesis()
+	genState.Balances = []types.Balance{
+		{Address: alice.String(), Denom: "useed", Amount: sdk.NewInt(400)},
+		{Address: bob.String(), Denom: "useed", Amount: sdk.NewInt(100)},
+		{Address: bob.String(), Denom: "upublic", Amount: sdk.NewInt(500)},
+	}
+	genState.Supply = []types.DenomSupply{
+		{Denom: "useed", Amount: sdk.NewInt(500)},
+		{Denom: "upublic", Amount: sdk.NewInt(500)},
+	}
+	k.InitGenesis(ctx, *genState)
+
+	exported := k.ExportGenesis(ctx)
+	require.ElementsMatch(t, genState.Balances, exported.Balances)
+	require.ElementsMatch(t, genState.Supply, exported.Supply)
+}
+
+func TestGenesisRejectsLongAddress(t *testing.T) {
+	k, ctx := setupKeeper(t)
+
+	genState := types.DefaultGenesis()
+	genState.Balances = []types.Balance{
+		{Address: alice.String(), Denom: "useed", Amount: sdk.NewInt(400)},
+		{Address: moduleAddr.String(), Denom: "useed", Amount: sdk.NewInt(100)},
+	}
+	genState.Supply = []types.DenomSupply{{Denom: "useed", Amount: sdk.NewInt(500)}}
+
+	err := genState.Validate()
+	require.ErrorIs(t, err, types.ErrInvalidGenesis)
+	require.Contains(t, err.Error(), "balances are limited to 20-byte addresses")
+	require.Panics(t, func() { k.InitGenesis(ctx, *genState) })
+
+	// Nothing was written, so the export is still valid and empty
+	require.Empty(t, k.ExportGenesis(ctx).Balances)
+}
+
+func TestMintRejectsLongAddress(t *testing.T) {
+	k, ctx := setupKeeper(t)
+
+	err := k.Mint(ctx, moduleAddr, "useed", sdk.NewInt(100))
+	require.ErrorIs(t, err, types.ErrInvalidAddress)
+
+	require.NoError(t, k.Mint(ctx, alice, "useed", sdk.NewInt(100)))
+	err = k.Transfer(ctx, alice, moduleAddr, "useed", sdk.NewInt(50))
+	require.ErrorIs(t, err, types.ErrInvalidAddress)
+}
@@ -0,0 +1,25 @@
+package keeper
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/example/token/x/token/types"
+)
+
+// IsRegistered implements the Query/IsRegistered gRPC method
+func (k Keeper) IsRegistered(c context.Context, req *types.QueryIsRegisteredRequest) (*types.QueryIsRegisteredResponse, error) {
+	if req == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "empty request")
+	}
+
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidAddress, "invalid address: %s", err)
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	return &types.QueryIsRegisteredResponse{Registered: k.IsAccountRegistered(ctx, addr)}, nil
+}
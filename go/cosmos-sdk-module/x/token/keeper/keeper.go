@@ -16,6 +16,9 @@ type Keeper struct {
 	cdc      codec.BinaryCodec
 	storeKey storetypes.StoreKey
 	memKey   storetypes.StoreKey
+
+	// authority is the address allowed to manage the verified account registry
+	authority string
 }
 
 // NewKeeper creates a new token Keeper instance
@@ -23,14 +26,21 @@ func NewKeeper(
 	cdc codec.BinaryCodec,
 	storeKey,
 	memKey storetypes.StoreKey,
+	authority string,
 ) *Keeper {
 	return &Keeper{
-		cdc:      cdc,
-		storeKey: storeKey,
-		memKey:   memKey,
+		cdc:       cdc,
+		storeKey:  storeKey,
+		memKey:    memKey,
+		authority: authority,
 	}
 }
 
+// GetAuthority returns the registry authority address
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
 // Logger returns a module-specific logger
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
@@ -46,9 +56,7 @@ func (k Keeper) GetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string) s
 		return sdk.ZeroInt()
 	}
 
-	var balance sdk.Int
-	k.cdc.MustUnmarshal(bz, &balance)
-	return balance
+	return mustUnmarshalInt(bz)
 }
 
 // SetBalance sets the balance of an account
@@ -56,8 +64,26 @@ func (k Keeper) SetBalance(ctx sdk.Context, addr sdk.AccAddress, denom string, a
 	store := ctx.KVStore(k.storeKey)
 	key := types.BalanceKey(addr, denom)
 
-	bz := k.cdc.MustMarshal(&amount)
-	store.Set(key, bz)
+	store.Set(key, mustMarshalInt(amount))
+}
+
+// mustMarshalInt encodes a balance amount. sdk.Int is not a proto message, so
+// it is encoded with its own Marshal, the same bytes a proto field holds.
+func mustMarshalInt(amount sdk.Int) []byte {
+	bz, err := amount.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// mustUnmarshalInt decodes a balance amount written by mustMarshalInt
+func mustUnmarshalInt(bz []byte) sdk.Int {
+	var amount sdk.Int
+	if err := amount.Unmarshal(bz); err != nil {
+		panic(err)
+	}
+	return amount
 }
 
 // Transfer transfers tokens from one account to another
@@ -66,6 +92,14 @@ func (k Keeper) Transfer(ctx sdk.Context, from, to sdk.AccAddress, denom string,
 		return types.ErrInvalidAmount
 	}
 
+	if err := types.ValidateBalanceAddress(to); err != nil {
+		return err
+	}
+
+	if err := k.checkRegistration(ctx, denom, from, to); err != nil {
+		return err
+	}
+
 	fromBalance := k.GetBalance(ctx, from, denom)
 	if fromBalance.LT(amount) {
 		return types.ErrInsufficientBalance
@@ -96,6 +130,14 @@ func (k Keeper) Mint(ctx sdk.Context, addr sdk.AccAddress, denom string, amount
 		return types.ErrInvalidAmount
 	}
 
+	if err := types.ValidateBalanceAddress(addr); err != nil {
+		return err
+	}
+
+	if err := k.checkRegistration(ctx, denom, addr); err != nil {
+		return err
+	}
+
 	balance := k.GetBalance(ctx, addr, denom)
 	k.SetBalance(ctx, addr, denom, balance.Add(amount))
 
@@ -146,8 +188,7 @@ func (k Keeper) GetAllBalances(ctx sdk.Context, addr sdk.AccAddress) []types.Bal
 
 	balances := []types.Balance{}
 	for ; iterator.Valid(); iterator.Next() {
-		var amount sdk.Int
-		k.cdc.MustUnmarshal(iterator.Value(), &amount)
+		amount := mustUnmarshalInt(iterator.Value())
 
 		denom := string(iterator.Key()[len(types.BalancesPrefix(addr)):])
 		balances = append(balances, types.Balance{
@@ -0,0 +1,34 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	"github.com/cosmos/cosmos-sdk/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/example/token/x/token/keeper"
+	"github.com/example/token/x/token/types"
+)
+
+var (
+	authority = sdk.AccAddress("authority___________")
+	alice     = sdk.AccAddress("alice_______________")
+	bob       = sdk.AccAddress("bob_________________")
+	carol     = sdk.AccAddress("carol_______________")
+)
+
+// setupKeeper returns a keeper backed by an in-memory store, with authority
+// as the registry authority
+func setupKeeper(t *testing.T) (*keeper.Keeper, sdk.Context) {
+	t.Helper()
+
+	storeKey := storetypes.NewKVStoreKey(types.StoreKey)
+	memKey := storetypes.NewMemoryStoreKey(types.MemStoreKey)
+	ctx := testutil.DefaultContext(storeKey, storetypes.NewTransientStoreKey("transient_test"))
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+
+	return keeper.NewKeeper(cdc, storeKey, memKey, authority.String()), ctx
+}
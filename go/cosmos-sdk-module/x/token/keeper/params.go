@@ -0,0 +1,29 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/example/token/x/token/types"
+)
+
+// GetParams returns the module parameters, falling back to the defaults when unset
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(types.ParamsKey)
+	if bz == nil {
+		return types.DefaultParams()
+	}
+
+	var params types.Params
+	types.ModuleCdc.LegacyAmino.MustUnmarshal(bz, &params)
+	return params
+}
+
+// SetParams sets the module parameters
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := types.ModuleCdc.LegacyAmino.MustMarshal(&params)
+	store.Set(types.ParamsKey, bz)
+}
@@ -0,0 +1,104 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/example/token/x/token/types"
+)
+
+// IsAccountRegistered reports whether an account is in the verified account registry
+func (k Keeper) IsAccountRegistered(ctx sdk.Context, addr sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.RegistryKey(addr))
+}
+
+// RegisterAccount adds an account to the verified account registry
+func (k Keeper) RegisterAccount(ctx sdk.Context, authority string, addr sdk.AccAddress) error {
+	if authority != k.authority {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "expected %s, got %s", k.authority, authority)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.RegistryKey(addr), []byte{0x01})
+
+	// Emit register event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRegister,
+			sdk.NewAttribute(types.AttributeKeyAccount, addr.String()),
+		),
+	)
+
+	return nil
+}
+
+// DeregisterAccount removes an account from the verified account registry
+func (k Keeper) DeregisterAccount(ctx sdk.Context, authority string, addr sdk.AccAddress) error {
+	if authority != k.authority {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "expected %s, got %s", k.authority, authority)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.RegistryKey(addr))
+
+	// Emit deregister event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeDeregister,
+			sdk.NewAttribute(types.AttributeKeyAccount, addr.String()),
+		),
+	)
+
+	return nil
+}
+
+// GetAllRegisteredAccounts returns every account in the verified account registry
+func (k Keeper) GetAllRegisteredAccounts(ctx sdk.Context) []string {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.RegistryKeyPrefix)
+	defer iterator.Close()
+
+	accounts := []string{}
+	for ; iterator.Valid(); iterator.Next() {
+		addr := sdk.AccAddress(iterator.Key()[len(types.RegistryKeyPrefix):])
+		accounts = append(accounts, addr.String())
+	}
+
+	return accounts
+}
+
+// SetDenomRequiresRegistration toggles whether a denom can only be transferred
+// and minted between registered accounts. Only the denom admin may change it.
+func (k Keeper) SetDenomRequiresRegistration(ctx sdk.Context, admin sdk.AccAddress, denom string, required bool) error {
+	metadata, found := k.GetDenomMetadata(ctx, denom)
+	if !found {
+		return sdkerrors.Wrapf(types.ErrDenomNotFound, "denom %s", denom)
+	}
+
+	if metadata.Admin != admin.String() {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not the admin of %s", admin, denom)
+	}
+
+	metadata.RequiresRegistration = required
+	k.SetDenomMetadata(ctx, metadata)
+
+	return nil
+}
+
+// checkRegistration returns ErrAccountNotRegistered if the denom requires
+// registration and any of the given accounts is not registered
+func (k Keeper) checkRegistration(ctx sdk.Context, denom string, addrs ...sdk.AccAddress) error {
+	metadata, found := k.GetDenomMetadata(ctx, denom)
+	if !found || !metadata.RequiresRegistration {
+		return nil
+	}
+
+	for _, addr := range addrs {
+		if !k.IsAccountRegistered(ctx, addr) {
+			return sdkerrors.Wrapf(types.ErrAccountNotRegistered, "%s cannot hold %s", addr, denom)
+		}
+	}
+
+	return nil
+}
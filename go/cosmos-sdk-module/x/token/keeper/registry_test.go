@@ -0,0 +1,88 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/example/token/x/token/keeper"
+	"github.com/example/token/x/token/types"
+)
+
+// setupRegistry creates a denom administered by alice that requires
+// registration, funds alice, and registers alice and bob but not carol
+func setupRegistry(t *testing.T) (*keeper.Keeper, sdk.Context) {
+	t.Helper()
+
+	k, ctx := setupKeeper(t)
+	require.NoError(t, k.CreateDenom(ctx, alice, "ukyc", "KYC"))
+	require.NoError(t, k.Mint(ctx, alice, "ukyc", sdk.NewInt(1000)))
+	require.NoError(t, k.RegisterAccount(ctx, authority.String(), alice))
+	require.NoError(t, k.RegisterAccount(ctx, authority.String(), bob))
+	require.NoError(t, k.SetDenomRequiresRegistration(ctx, alice, "ukyc", true))
+
+	return k, ctx
+}
+
+func TestTransferBetweenRegisteredAccounts(t *testing.T) {
+	k, ctx := setupRegistry(t)
+
+	require.NoError(t, k.Transfer(ctx, alice, bob, "ukyc", sdk.NewInt(100)))
+	require.Equal(t, sdk.NewInt(900), k.GetBalance(ctx, alice, "ukyc"))
+	require.Equal(t, sdk.NewInt(100), k.GetBalance(ctx, bob, "ukyc"))
+}
+
+func TestTransferWithUnregisteredParty(t *testing.T) {
+	k, ctx := setupRegistry(t)
+
+	err := k.Transfer(ctx, alice, carol, "ukyc", sdk.NewInt(100))
+	require.ErrorIs(t, err, types.ErrAccountNotRegistered)
+
+	err = k.Mint(ctx, carol, "ukyc", sdk.NewInt(100))
+	require.ErrorIs(t, err, types.ErrAccountNotRegistered)
+
+	// A deregistered account loses access too
+	require.NoError(t, k.DeregisterAccount(ctx, authority.String(), bob))
+	err = k.Transfer(ctx, alice, bob, "ukyc", sdk.NewInt(100))
+	require.ErrorIs(t, err, types.ErrAccountNotRegistered)
+
+	require.Equal(t, sdk.NewInt(1000), k.GetBalance(ctx, alice, "ukyc"))
+}
+
+func TestSetDenomRequiresRegistration(t *testing.T) {
+	k, ctx := setupRegistry(t)
+
+	require.NoError(t, k.SetDenomRequiresRegistration(ctx, alice, "ukyc", false))
+	metadata, found := k.GetDenomMetadata(ctx, "ukyc")
+	require.True(t, found)
+	require.False(t, metadata.RequiresRegistration)
+	require.NoError(t, k.Transfer(ctx, alice, carol, "ukyc", sdk.NewInt(100)))
+
+	require.NoError(t, k.SetDenomRequiresRegistration(ctx, alice, "ukyc", true))
+	err := k.Transfer(ctx, carol, bob, "ukyc", sdk.NewInt(100))
+	require.ErrorIs(t, err, types.ErrAccountNotRegistered)
+
+	// Only the denom admin can change the flag
+	err = k.SetDenomRequiresRegistration(ctx, bob, "ukyc", false)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+
+	err = k.SetDenomRequiresRegistration(ctx, alice, "unknown", true)
+	require.ErrorIs(t, err, types.ErrDenomNotFound)
+}
+
+func TestRegistryRequiresAuthority(t *testing.T) {
+	k, ctx := setupKeeper(t)
+
+	err := k.RegisterAccount(ctx, alice.String(), carol)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	require.False(t, k.IsAccountRegistered(ctx, carol))
+
+	require.NoError(t, k.RegisterAccount(ctx, authority.String(), carol))
+	require.True(t, k.IsAccountRegistered(ctx, carol))
+
+	err = k.DeregisterAccount(ctx, alice.String(), carol)
+	require.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	require.True(t, k.IsAccountRegistered(ctx, carol))
+}
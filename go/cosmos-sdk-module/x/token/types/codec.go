@@ -12,6 +12,10 @@ func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgTransfer{}, "token/Transfer", nil)
 	cdc.RegisterConcrete(&MsgMint{}, "token/Mint", nil)
 	cdc.RegisterConcrete(&MsgBurn{}, "token/Burn", nil)
+	cdc.RegisterConcrete(&MsgCreateDenom{}, "token/CreateDenom", nil)
+	cdc.RegisterConcrete(&MsgRegisterAccount{}, "token/RegisterAccount", nil)
+	cdc.RegisterConcrete(&MsgDeregisterAccount{}, "token/DeregisterAccount", nil)
+	cdc.RegisterConcrete(&MsgSetDenomRequiresRegistration{}, "token/SetDenomRequiresRegistration", nil)
 }
 
 // RegisterInterfaces registers the module's interface types
@@ -20,6 +24,10 @@ func RegisterInterfaces(registry cdctypes.InterfaceRegistry) {
 		&MsgTransfer{},
 		&MsgMint{},
 		&MsgBurn{},
+		&MsgCreateDenom{},
+		&MsgRegisterAccount{},
+		&MsgDeregisterAccount{},
+		&MsgSetDenomRequiresRegistration{},
 	)
 
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxSymbolLength is the maximum length of a denom symbol
+const MaxSymbolLength = 32
+
+// DenomMetadata holds the registration details of a created denom
+type DenomMetadata struct {
+	Denom  string `json:"denom" yaml:"denom"`
+	Symbol string `json:"symbol" yaml:"symbol"`
+	Admin  string `json:"admin" yaml:"admin"`
+
+	// RequiresRegistration restricts transfers and mints of the denom to
+	// accounts in the verified account registry
+	RequiresRegistration bool `json:"requires_registration" yaml:"requires_registration"`
+}
+
+// Validate validates denom metadata
+func (m DenomMetadata) Validate() error {
+	if err := sdk.ValidateDenom(m.Denom); err != nil {
+		return err
+	}
+
+	if _, err := sdk.AccAddressFromBech32(m.Admin); err != nil {
+		return fmt.Errorf("invalid admin address: %w", err)
+	}
+
+	return ValidateSymbol(m.Symbol)
+}
+
+// ValidateSymbol checks that a symbol is non-empty, bounded and printable
+func ValidateSymbol(symbol string) error {
+	if symbol == "" || len(symbol) > MaxSymbolLength {
+		return fmt.Errorf("%w: symbol must be 1-%d bytes", ErrInvalidSymbol, MaxSymbolLength)
+	}
+
+	for _, r := range symbol {
+		if !unicode.IsPrint(r) || unicode.IsSpace(r) {
+			return fmt.Errorf("%w: symbol contains non-printable or space character %q", ErrInvalidSymbol, r)
+		}
+	}
+
+	return nil
+}
+
+// homoglyphs maps characters commonly used to imitate ASCII letters to the
+// letter they imitate. Inputs are NFKC-normalized and lower-cased first, so
+// fullwidth and upper-case variants do not need their own entries. Digits and
+// '$' are left alone: tickers such as "A1" and "USD0" are legitimate and must
+// not collide with "AL" and "USDO".
+var homoglyphs = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h',
+	'о': 'o', 'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'i',
+	'ї': 'i', 'ј': 'j', 'ѕ': 's', 'һ': 'h', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w',
+	// Greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v',
+	'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'ζ': 'z',
+	// Symbols standing in for letters
+	'|': 'l',
+	// Latin look-alikes
+	'ı': 'i', 'ℓ': 'l', 'ɡ': 'g',
+}
+
+// NormalizeSymbol folds a symbol into the canonical form used for collision
+// checks: NFKC-normalized, case-folded and with homoglyphs mapped to ASCII,
+// so that "USDC", "usdc" and "UЅDС" (Cyrillic Ѕ and С) all normalize alike.
+func NormalizeSymbol(symbol string) string {
+	folded := strings.ToLower(norm.NFKC.String(symbol))
+
+	var b strings.Builder
+	for _, r := range folded {
+		if mapped, ok := homoglyphs[r]; ok {
+			r = mapped
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
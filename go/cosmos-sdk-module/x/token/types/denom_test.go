@@ -0,0 +1,32 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/example/token/x/token/types"
+)
+
+func TestNormalizeSymbol(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		collide bool
+	}{
+		{"USDC", "usdc", true},
+		{"USDC", "ＵＳＤＣ", true},
+		{"USDC", "UЅDС", true}, // Cyrillic Ѕ and С
+		{"ATOM", "аtom", true}, // Cyrillic а
+		{"USDC", "USDT", false},
+		{"A1", "AL", false},
+		{"USD0", "USDO", false},
+		{"US$", "USS", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.a+"/"+tc.b, func(t *testing.T) {
+			equal := types.NormalizeSymbol(tc.a) == types.NormalizeSymbol(tc.b)
+			require.Equal(t, tc.collide, equal)
+		})
+	}
+}
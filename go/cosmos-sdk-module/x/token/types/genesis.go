@@ -0,0 +1,162 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// GenesisState defines the token module's genesis state
+type GenesisState struct {
+	Params             Params                    `json:"params" yaml:"params"`
+	Denoms             []DenomMetadata           `json:"denoms" yaml:"denoms"`
+	RegisteredAccounts []string                  `json:"registered_accounts" yaml:"registered_accounts"`
+	Balances           []Balance                 `json:"balances" yaml:"balances"`
+	Supply             []DenomSupply             `json:"supply" yaml:"supply"`
+	SupplyConstraints  []GenesisSupplyConstraint `json:"supply_constraints" yaml:"supply_constraints"`
+}
+
+// DenomSupply declares the expected total supply of a single denom
+type DenomSupply struct {
+	Denom  string  `json:"denom" yaml:"denom"`
+	Amount sdk.Int `json:"amount" yaml:"amount"`
+}
+
+// GenesisSupplyConstraint requires the summed supply of a group of related
+// denoms to equal an expected total, e.g. all tranches of a multi-token launch
+type GenesisSupplyConstraint struct {
+	Name          string   `json:"name" yaml:"name"`
+	Denoms        []string `json:"denoms" yaml:"denoms"`
+	ExpectedTotal sdk.Int  `json:"expected_total" yaml:"expected_total"`
+}
+
+// NewGenesisState creates a new GenesisState instance
+func NewGenesisState(params Params, denoms []DenomMetadata, registered []string, balances []Balance, supply []DenomSupply, constraints []GenesisSupplyConstraint) *GenesisState {
+	return &GenesisState{
+		Params:             params,
+		Denoms:             denoms,
+		RegisteredAccounts: registered,
+		Balances:           balances,
+		Supply:             supply,
+		SupplyConstraints:  constraints,
+	}
+}
+
+// DefaultGenesis returns the default token genesis state
+func DefaultGenesis() *GenesisState {
+	return NewGenesisState(DefaultParams(), []DenomMetadata{}, []string{}, []Balance{}, []DenomSupply{}, []GenesisSupplyConstraint{})
+}
+
+// Validate performs basic genesis state validation, checking that the balances
+// add up to every declared denom supply and satisfy every supply constraint
+func (gs GenesisState) Validate() error {
+	if err := gs.Params.Validate(); err != nil {
+		return sdkerrors.Wrapf(ErrInvalidGenesis, "params: %s", err)
+	}
+
+	createdDenoms := make(map[string]bool)
+	for _, metadata := range gs.Denoms {
+		if err := metadata.Validate(); err != nil {
+			return sdkerrors.Wrapf(ErrInvalidGenesis, "denom %s: %s", metadata.Denom, err)
+		}
+		if createdDenoms[metadata.Denom] {
+			return sdkerrors.Wrapf(ErrInvalidGenesis, "duplicate metadata for denom %s", metadata.Denom)
+		}
+		createdDenoms[metadata.Denom] = true
+	}
+
+	registered := make(map[string]bool)
+	for _, account := range gs.RegisteredAccounts {
+		if _, err := sdk.AccAddressFromBech32(account); err != nil {
+			return sdkerrors.Wrapf(ErrInvalidGenesis, "registered account %s: %s", account, err)
+		}
+		if registered[account] {
+			return sdkerrors.Wrapf(ErrInvalidGenesis, "duplicate registered account %s", account)
+		}
+		registered[account] = true
+	}
+
+	totals := make(map[string]sdk.Int)
+	seen := make(map[string]bool)
+	for _, balance := range gs.Balances {
+		if err := balance.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(ErrInvalidGenesis, "balance %s/%s: %s", balance.Address, balance.Denom, err)
+		}
+
+		key := balance.Address + "/" + balance.Denom
+		if seen[key] {
+			return sdkerrors.Wrapf(ErrInvalidGenesis, "duplicate balance for %s", key)
+		}
+		seen[key] = true
+
+		total, ok := totals[balance.Denom]
+		if !ok {
+			total = sdk.ZeroInt()
+		}
+		totals[balance.Denom] = total.Add(balance.Amount)
+	}
+
+	declared := make(map[string]bool)
+	for _, supply := range gs.Supply {
+		if err := sdk.ValidateDenom(supply.Denom); err != nil {
+			return sdkerrors.Wrapf(ErrInvalidGenesis, "supply: %s", err)
+		}
+		if declared[supply.Denom] {
+			return sdkerrors.Wrapf(ErrInvalidGenesis, "duplicate supply for denom %s", supply.Denom)
+		}
+		declared[supply.Denom] = true
+
+		if actual := supplyOf(totals, supply.Denom); !actual.Equal(supply.Amount) {
+			return sdkerrors.Wrapf(ErrInvalidGenesis, "denom %s: balances total %s, declared supply %s", supply.Denom, actual, supply.Amount)
+		}
+	}
+
+	for _, constraint := range gs.SupplyConstraints {
+		if err := constraint.Check(totals); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Check verifies the constraint against the given per-denom supplies and
+// reports the exact shortfall or excess when it is not satisfied
+func (c GenesisSupplyConstraint) Check(supplies map[string]sdk.Int) error {
+	if c.Name == "" {
+		return sdkerrors.Wrap(ErrInvalidGenesis, "supply constraint name cannot be empty")
+	}
+	if len(c.Denoms) == 0 {
+		return sdkerrors.Wrapf(ErrInvalidGenesis, "supply constraint %q has no denoms", c.Name)
+	}
+	if c.ExpectedTotal.IsNil() || c.ExpectedTotal.IsNegative() {
+		return sdkerrors.Wrapf(ErrInvalidGenesis, "supply constraint %q has an invalid expected total", c.Name)
+	}
+
+	total := sdk.ZeroInt()
+	seen := make(map[string]bool)
+	for _, denom := range c.Denoms {
+		if seen[denom] {
+			return sdkerrors.Wrapf(ErrInvalidGenesis, "supply constraint %q lists denom %s twice", c.Name, denom)
+		}
+		seen[denom] = true
+		total = total.Add(supplyOf(supplies, denom))
+	}
+
+	switch {
+	case total.LT(c.ExpectedTotal):
+		return sdkerrors.Wrapf(ErrInvalidGenesis, "supply constraint %q: total %s is %s short of expected %s",
+			c.Name, total, c.ExpectedTotal.Sub(total), c.ExpectedTotal)
+	case total.GT(c.ExpectedTotal):
+		return sdkerrors.Wrapf(ErrInvalidGenesis, "supply constraint %q: total %s exceeds expected %s by %s",
+			c.Name, total, c.ExpectedTotal, total.Sub(c.ExpectedTotal))
+	}
+
+	return nil
+}
+
+func supplyOf(supplies map[string]sdk.Int, denom string) sdk.Int {
+	if supply, ok := supplies[denom]; ok {
+		return supply
+	}
+	return sdk.ZeroInt()
+}
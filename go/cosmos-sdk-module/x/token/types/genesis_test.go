@@ -0,0 +1,71 @@
+package types_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/example/token/x/token/types"
+)
+
+func TestGenesisSupplyConstraintCheck(t *testing.T) {
+	supplies := map[string]sdk.Int{
+		"useed":   sdk.NewInt(400000),
+		"upublic": sdk.NewInt(600000),
+	}
+
+	tests := []struct {
+		name       string
+		constraint types.GenesisSupplyConstraint
+		err        string
+	}{
+		{
+			name: "satisfied",
+			constraint: types.GenesisSupplyConstraint{
+				Name:          "launch",
+				Denoms:        []string{"useed", "upublic"},
+				ExpectedTotal: sdk.NewInt(1000000),
+			},
+		},
+		{
+			name: "shortfall",
+			constraint: types.GenesisSupplyConstraint{
+				Name:          "launch",
+				Denoms:        []string{"useed", "upublic"},
+				ExpectedTotal: sdk.NewInt(1000250),
+			},
+			err: `supply constraint "launch": total 1000000 is 250 short of expected 1000250`,
+		},
+		{
+			name: "excess",
+			constraint: types.GenesisSupplyConstraint{
+				Name:          "launch",
+				Denoms:        []string{"useed", "upublic"},
+				ExpectedTotal: sdk.NewInt(999000),
+			},
+			err: `supply constraint "launch": total 1000000 exceeds expected 999000 by 1000`,
+		},
+		{
+			name: "missing denom counts as zero",
+			constraint: types.GenesisSupplyConstraint{
+				Name:          "launch",
+				Denoms:        []string{"useed", "uteam"},
+				ExpectedTotal: sdk.NewInt(500000),
+			},
+			err: `supply constraint "launch": total 400000 is 100000 short of expected 500000`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.constraint.Check(supplies)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, types.ErrInvalidGenesis)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
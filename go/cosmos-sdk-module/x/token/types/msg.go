@@ -7,15 +7,24 @@ import (
 
 // Message types for the token module
 const (
-	TypeMsgTransfer = "transfer"
-	TypeMsgMint     = "mint"
-	TypeMsgBurn     = "burn"
+	TypeMsgTransfer    = "transfer"
+	TypeMsgMint        = "mint"
+	TypeMsgBurn        = "burn"
+	TypeMsgCreateDenom = "create_denom"
+
+	TypeMsgRegisterAccount              = "register_account"
+	TypeMsgDeregisterAccount            = "deregister_account"
+	TypeMsgSetDenomRequiresRegistration = "set_denom_requires_registration"
 )
 
 var (
 	_ sdk.Msg = &MsgTransfer{}
 	_ sdk.Msg = &MsgMint{}
 	_ sdk.Msg = &MsgBurn{}
+	_ sdk.Msg = &MsgCreateDenom{}
+	_ sdk.Msg = &MsgRegisterAccount{}
+	_ sdk.Msg = &MsgDeregisterAccount{}
+	_ sdk.Msg = &MsgSetDenomRequiresRegistration{}
 )
 
 // MsgTransfer defines a message to transfer tokens
@@ -182,3 +191,194 @@ func (msg MsgBurn) ValidateBasic() error {
 
 	return nil
 }
+
+// MsgCreateDenom defines a message to register a new denom with a display symbol
+type MsgCreateDenom struct {
+	Creator string `json:"creator" yaml:"creator"`
+	Denom   string `json:"denom" yaml:"denom"`
+	Symbol  string `json:"symbol" yaml:"symbol"`
+}
+
+// NewMsgCreateDenom creates a new MsgCreateDenom instance
+func NewMsgCreateDenom(creator, denom, symbol string) *MsgCreateDenom {
+	return &MsgCreateDenom{
+		Creator: creator,
+		Denom:   denom,
+		Symbol:  symbol,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgCreateDenom) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgCreateDenom) Type() string { return TypeMsgCreateDenom }
+
+// GetSigners implements sdk.Msg
+func (msg MsgCreateDenom) GetSigners() []sdk.AccAddress {
+	creator, err := sdk.AccAddressFromBech32(msg.Creator)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{creator}
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgCreateDenom) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgCreateDenom) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Creator); err != nil {
+		return sdkerrors.Wrapf(ErrInvalidAddress, "invalid creator address: %s", err)
+	}
+
+	if err := sdk.ValidateDenom(msg.Denom); err != nil {
+		return err
+	}
+
+	return ValidateSymbol(msg.Symbol)
+}
+
+// MsgRegisterAccount defines a message for the registry authority to add an account to the
+// chain-wide verified account registry
+type MsgRegisterAccount struct {
+	Authority string `json:"authority" yaml:"authority"`
+	Address   string `json:"address" yaml:"address"`
+}
+
+// NewMsgRegisterAccount creates a new MsgRegisterAccount instance
+func NewMsgRegisterAccount(authority, addr string) *MsgRegisterAccount {
+	return &MsgRegisterAccount{
+		Authority: authority,
+		Address:   addr,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgRegisterAccount) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgRegisterAccount) Type() string { return TypeMsgRegisterAccount }
+
+// GetSigners implements sdk.Msg
+func (msg MsgRegisterAccount) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{authority}
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgRegisterAccount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgRegisterAccount) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrapf(ErrInvalidAddress, "invalid authority address: %s", err)
+	}
+
+	if _, err := sdk.AccAddressFromBech32(msg.Address); err != nil {
+		return sdkerrors.Wrapf(ErrInvalidAddress, "invalid address: %s", err)
+	}
+
+	return nil
+}
+
+// MsgDeregisterAccount defines a message for the registry authority to remove an account from the
+// chain-wide verified account registry
+type MsgDeregisterAccount struct {
+	Authority string `json:"authority" yaml:"authority"`
+	Address   string `json:"address" yaml:"address"`
+}
+
+// NewMsgDeregisterAccount creates a new MsgDeregisterAccount instance
+func NewMsgDeregisterAccount(authority, addr string) *MsgDeregisterAccount {
+	return &MsgDeregisterAccount{
+		Authority: authority,
+		Address:   addr,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgDeregisterAccount) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgDeregisterAccount) Type() string { return TypeMsgDeregisterAccount }
+
+// GetSigners implements sdk.Msg
+func (msg MsgDeregisterAccount) GetSigners() []sdk.AccAddress {
+	authority, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{authority}
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgDeregisterAccount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgDeregisterAccount) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrapf(ErrInvalidAddress, "invalid authority address: %s", err)
+	}
+
+	if _, err := sdk.AccAddressFromBech32(msg.Address); err != nil {
+		return sdkerrors.Wrapf(ErrInvalidAddress, "invalid address: %s", err)
+	}
+
+	return nil
+}
+
+// MsgSetDenomRequiresRegistration defines a message for a denom admin to restrict, or stop
+// restricting, transfers and mints of the denom to registered accounts
+type MsgSetDenomRequiresRegistration struct {
+	Admin                string `json:"admin" yaml:"admin"`
+	Denom                string `json:"denom" yaml:"denom"`
+	RequiresRegistration bool   `json:"requires_registration" yaml:"requires_registration"`
+}
+
+// NewMsgSetDenomRequiresRegistration creates a new MsgSetDenomRequiresRegistration instance
+func NewMsgSetDenomRequiresRegistration(admin, denom string, required bool) *MsgSetDenomRequiresRegistration {
+	return &MsgSetDenomRequiresRegistration{
+		Admin:                admin,
+		Denom:                denom,
+		RequiresRegistration: required,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgSetDenomRequiresRegistration) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgSetDenomRequiresRegistration) Type() string { return TypeMsgSetDenomRequiresRegistration }
+
+// GetSigners implements sdk.Msg
+func (msg MsgSetDenomRequiresRegistration) GetSigners() []sdk.AccAddress {
+	admin, err := sdk.AccAddressFromBech32(msg.Admin)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{admin}
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgSetDenomRequiresRegistration) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgSetDenomRequiresRegistration) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Admin); err != nil {
+		return sdkerrors.Wrapf(ErrInvalidAddress, "invalid admin address: %s", err)
+	}
+
+	return sdk.ValidateDenom(msg.Denom)
+}
@@ -0,0 +1,25 @@
+package types
+
+// Params defines the parameters for the token module
+type Params struct {
+	// DenomSymbolCollisionCheck rejects new denoms whose normalized symbol
+	// matches an existing denom's symbol, unless the creator is that denom's admin
+	DenomSymbolCollisionCheck bool `json:"denom_symbol_collision_check" yaml:"denom_symbol_collision_check"`
+}
+
+// NewParams creates a new Params instance
+func NewParams(denomSymbolCollisionCheck bool) Params {
+	return Params{
+		DenomSymbolCollisionCheck: denomSymbolCollisionCheck,
+	}
+}
+
+// DefaultParams returns the default token module parameters
+func DefaultParams() Params {
+	return NewParams(false)
+}
+
+// Validate validates the parameters
+func (p Params) Validate() error {
+	return nil
+}
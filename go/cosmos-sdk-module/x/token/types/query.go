@@ -0,0 +1,11 @@
+package types
+
+// QueryIsRegisteredRequest is the request type for the Query/IsRegistered method
+type QueryIsRegisteredRequest struct {
+	Address string `json:"address" yaml:"address"`
+}
+
+// QueryIsRegisteredResponse is the response type for the Query/IsRegistered method
+type QueryIsRegisteredResponse struct {
+	Registered bool `json:"registered" yaml:"registered"`
+}
@@ -27,26 +27,50 @@ const (
 var (
 	// BalanceKeyPrefix is the prefix for balance keys
 	BalanceKeyPrefix = []byte{0x01}
+
+	// DenomMetadataKeyPrefix is the prefix for denom metadata keys
+	DenomMetadataKeyPrefix = []byte{0x02}
+
+	// SymbolIndexKeyPrefix is the prefix for the normalized-symbol index
+	SymbolIndexKeyPrefix = []byte{0x03}
+
+	// ParamsKey is the key for the module parameters
+	ParamsKey = []byte{0x04}
+
+	// RegistryKeyPrefix is the prefix for the verified account registry
+	RegistryKeyPrefix = []byte{0x05}
 )
 
 // Events
 const (
-	EventTypeTransfer = "transfer"
-	EventTypeMint     = "mint"
-	EventTypeBurn     = "burn"
+	EventTypeTransfer   = "transfer"
+	EventTypeMint       = "mint"
+	EventTypeBurn       = "burn"
+	EventTypeCreate     = "create_denom"
+	EventTypeRegister   = "register_account"
+	EventTypeDeregister = "deregister_account"
 
 	AttributeKeyFrom      = "from"
 	AttributeKeyTo        = "to"
 	AttributeKeyRecipient = "recipient"
 	AttributeKeyAmount    = "amount"
 	AttributeKeyDenom     = "denom"
+	AttributeKeySymbol    = "symbol"
+	AttributeKeyAdmin     = "admin"
+	AttributeKeyAccount   = "account"
 )
 
 // Errors
 var (
-	ErrInsufficientBalance = sdkerrors.Register(ModuleName, 1, "insufficient balance")
-	ErrInvalidAmount       = sdkerrors.Register(ModuleName, 2, "invalid amount")
-	ErrInvalidAddress      = sdkerrors.Register(ModuleName, 3, "invalid address")
+	ErrInsufficientBalance  = sdkerrors.Register(ModuleName, 1, "insufficient balance")
+	ErrInvalidAmount        = sdkerrors.Register(ModuleName, 2, "invalid amount")
+	ErrInvalidAddress       = sdkerrors.Register(ModuleName, 3, "invalid address")
+	ErrInvalidGenesis       = sdkerrors.Register(ModuleName, 4, "invalid genesis state")
+	ErrDenomExists          = sdkerrors.Register(ModuleName, 5, "denom already exists")
+	ErrSymbolCollision      = sdkerrors.Register(ModuleName, 6, "denom symbol collides with an existing denom")
+	ErrInvalidSymbol        = sdkerrors.Register(ModuleName, 7, "invalid symbol")
+	ErrDenomNotFound        = sdkerrors.Register(ModuleName, 8, "denom not found")
+	ErrAccountNotRegistered = sdkerrors.Register(ModuleName, 9, "account not registered")
 )
 
 // Balance represents an account balance
@@ -66,11 +90,56 @@ func BalancesPrefix(addr sdk.AccAddress) []byte {
 	return append(BalanceKeyPrefix, addr.Bytes()...)
 }
 
+// BalanceKeyAddrLen is the length of the account addresses in balance keys.
+// The keys hold the raw address bytes without a length prefix, so only
+// standard 20-byte account addresses may hold balances; see
+// ValidateBalanceAddress.
+const BalanceKeyAddrLen = 20
+
+// ValidateBalanceAddress rejects addresses that cannot hold a balance, such as
+// 32-byte module and derived addresses, whose keys could not be split again
+func ValidateBalanceAddress(addr sdk.AccAddress) error {
+	if len(addr) != BalanceKeyAddrLen {
+		return sdkerrors.Wrapf(ErrInvalidAddress, "%s is %d bytes, balances are limited to %d-byte addresses", addr, len(addr), BalanceKeyAddrLen)
+	}
+	return nil
+}
+
+// AddressAndDenomFromBalanceKey splits a balance store key into its address and denom
+func AddressAndDenomFromBalanceKey(key []byte) (sdk.AccAddress, string) {
+	key = key[len(BalanceKeyPrefix):]
+	return sdk.AccAddress(key[:BalanceKeyAddrLen]), string(key[BalanceKeyAddrLen:])
+}
+
+// DenomMetadataKey returns the store key for a denom's metadata
+func DenomMetadataKey(denom string) []byte {
+	return append(DenomMetadataKeyPrefix, []byte(denom)...)
+}
+
+// SymbolIndexPrefix returns the prefix for all denoms sharing a normalized symbol
+func SymbolIndexPrefix(normalizedSymbol string) []byte {
+	return append(append(SymbolIndexKeyPrefix, []byte(normalizedSymbol)...), 0x00)
+}
+
+// SymbolIndexKey returns the index key linking a normalized symbol to a denom
+func SymbolIndexKey(normalizedSymbol, denom string) []byte {
+	return append(SymbolIndexPrefix(normalizedSymbol), []byte(denom)...)
+}
+
+// RegistryKey returns the store key marking an account as registered
+func RegistryKey(addr sdk.AccAddress) []byte {
+	return append(RegistryKeyPrefix, addr.Bytes()...)
+}
+
 // ValidateBasic validates a balance
 func (b Balance) ValidateBasic() error {
-	if _, err := sdk.AccAddressFromBech32(b.Address); err != nil {
+	addr, err := sdk.AccAddressFromBech32(b.Address)
+	if err != nil {
 		return fmt.Errorf("invalid address: %w", err)
 	}
+	if err := ValidateBalanceAddress(addr); err != nil {
+		return err
+	}
 
 	if err := sdk.ValidateDenom(b.Denom); err != nil {
 		return err
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+// defaultExplorerAPIURL is Etherscan's multichain API, which serves every
+// chain it indexes with one key, selected by the chainid parameter
+const defaultExplorerAPIURL = "https://api.etherscan.io/v2/api"
+
+// explorerAPIKeyEnv is read when --explorer-api-key is not given
+const explorerAPIKeyEnv = "ETHERSCAN_API_KEY"
+
+// explorerTimeout bounds an explorer API request
+const explorerTimeout = 15 * time.Second
+
+var (
+	abiSource      string
+	abiEtherscan   bool
+	explorerAPIKey string
+	explorerAPIURL string
+
+	// abiMemo holds the ABIs loaded or fetched during this run, keyed by
+	// --abi source or by chain ID and contract address
+	abiMemoMu sync.Mutex
+	abiMemo   = make(map[string]abi.ABI)
+)
+
+// addABIFlags registers --abi and the --abi-etherscan flags on a command that
+// decodes or encodes contract data
+func addABIFlags(cmd *cobra.Command, usage string) {
+	cmd.Flags().StringVar(&abiSource, "abi", "", usage)
+	cmd.Flags().BoolVar(&abiEtherscan, "abi-etherscan", false, "Fetch the contract's verified ABI from the block explorer instead of --abi")
+	cmd.Flags().StringVar(&explorerAPIKey, "explorer-api-key", "", "Block explorer API key for --abi-etherscan (default $"+explorerAPIKeyEnv+")")
+	cmd.Flags().StringVar(&explorerAPIURL, "explorer-api-url", defaultExplorerAPIURL, "Etherscan-compatible API used by --abi-etherscan")
+	cmd.MarkFlagsMutuallyExclusive("abi", "abi-etherscan")
+}
+
+// abiRequested reports whether an ABI was given with --abi or --abi-etherscan
+func abiRequested() bool {
+	return abiSource != "" || abiEtherscan
+}
+
+// ContractABI returns the ABI to use for a contract: the --abi file or inline
+// JSON, or with --abi-etherscan the contract's verified ABI. Each ABI is loaded
+// once per run.
+func (c *Client) ContractABI(contract common.Address) (abi.ABI, error) {
+	if !abiEtherscan {
+		return memoABI("source:"+abiSource, func() (abi.ABI, error) {
+			return loadABI(abiSource)
+		})
+	}
+
+	chainID, err := c.GetChainID()
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	return memoABI(fmt.Sprintf("explorer:%s:%s", chainID, contract.Hex()), func() (abi.ABI, error) {
+		return c.FetchVerifiedABI(contract)
+	})
+}
+
+// memoABI returns the ABI stored under key, loading it with load on first use
+func memoABI(key string, load func() (abi.ABI, error)) (abi.ABI, error) {
+	abiMemoMu.Lock()
+	defer abiMemoMu.Unlock()
+	if parsed, ok := abiMemo[key]; ok {
+		return parsed, nil
+	}
+	parsed, err := load()
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	abiMemo[key] = parsed
+	return parsed, nil
+}
+
+// abiCachePath returns the file a contract's fetched ABI is cached in, under
+// the user's cache directory. Verified source never changes, so entries do not
+// expire; --no-cache fetches them again.
+func abiCachePath(chainID fmt.Stringer, contract common.Address) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%s.json", chainID, strings.ToLower(contract.Hex()))
+	return filepath.Join(dir, "eth-rpc", "abi", name), nil
+}
+
+// FetchVerifiedABI returns the ABI of a contract verified on the chain's
+// Etherscan-style explorer, from the on-disk cache when it was fetched before
+func (c *Client) FetchVerifiedABI(contract common.Address) (abi.ABI, error) {
+	chainID, err := c.GetChainID()
+	if err != nil {
+		return abi.ABI{}, err
+	}
+
+	cachePath, cacheErr := abiCachePath(chainID, contract)
+	if cacheErr == nil && !noCache {
+		if data, err := os.ReadFile(cachePath); err == nil {
+			if parsed, err := abi.JSON(strings.NewReader(string(data))); err == nil {
+				return parsed, nil
+			}
+		}
+	}
+
+	key := explorerAPIKey
+	if key == "" {
+		key = os.Getenv(explorerAPIKeyEnv)
+	}
+	if key == "" {
+		return abi.ABI{}, fmt.Errorf("--abi-etherscan needs a block explorer API key: set --explorer-api-key or %s", explorerAPIKeyEnv)
+	}
+
+	endpoint, err := url.Parse(explorerAPIURL)
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("invalid --explorer-api-url: %w", err)
+	}
+	query := endpoint.Query()
+	query.Set("chainid", chainID.String())
+	query.Set("module", "contract")
+	query.Set("action", "getabi")
+	query.Set("address", contract.Hex())
+	query.Set("apikey", key)
+	endpoint.RawQuery = query.Encode()
+
+	ctx, cancel := context.WithTimeout(c.ctx, explorerTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		// The error includes the URL, and with it the API key
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return abi.ABI{}, fmt.Errorf("failed to fetch ABI of %s: %w", contract.Hex(), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return abi.ABI{}, fmt.Errorf("explorer API returned %s", resp.Status)
+	}
+
+	var body struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Result  string `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return abi.ABI{}, fmt.Errorf("invalid explorer API response: %w", err)
+	}
+	if body.Status != "1" {
+		return abi.ABI{}, fmt.Errorf("failed to fetch ABI of %s: %s", contract.Hex(), body.Result)
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(body.Result))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse ABI of %s: %w", contract.Hex(), err)
+	}
+
+	// The cache only saves requests, so failing to write it is not an error
+	if cacheErr == nil && os.MkdirAll(filepath.Dir(cachePath), 0o700) == nil {
+		os.WriteFile(cachePath, []byte(body.Result), 0o600)
+	}
+	return parsed, nil
+}
+
+// logDecoder returns a function decoding logs with --abi or, with
+// --abi-etherscan, the verified ABI of the contract that emitted each log. A
+// contract whose ABI cannot be fetched is noted once and its logs are left
+// undecoded.
+func (c *Client) logDecoder() (func(l *types.Log) *DecodedEvent, error) {
+	if !abiEtherscan {
+		contractABI, err := c.ContractABI(common.Address{})
+		if err != nil {
+			return nil, err
+		}
+		return func(l *types.Log) *DecodedEvent {
+			decoded, _ := decodeLog(contractABI, l)
+			return decoded
+		}, nil
+	}
+
+	var (
+		mu     sync.Mutex
+		failed = make(map[common.Address]bool)
+	)
+	return func(l *types.Log) *DecodedEvent {
+		mu.Lock()
+		defer mu.Unlock()
+		if failed[l.Address] {
+			return nil
+		}
+		contractABI, err := c.ContractABI(l.Address)
+		if err != nil {
+			failed[l.Address] = true
+			fmt.Fprintf(progressWriter(), "Note: logs of %s are not decoded: %v\n", l.Address.Hex(), err)
+			return nil
+		}
+		decoded, _ := decodeLog(contractABI, l)
+		return decoded
+	}, nil
+}
@@ -0,0 +1,397 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ABIValue is a decoded ABI argument or return value. Tuples and arrays also
+// list their fields or elements as Components.
+type ABIValue struct {
+	Name       string     `json:"name,omitempty"`
+	Type       string     `json:"type"`
+	Value      string     `json:"value"`
+	Components []ABIValue `json:"components,omitempty"`
+}
+
+// newABIValue describes a decoded value of ABI type t, naming tuple fields
+// after their ABI components and array elements by index
+func newABIValue(name string, t abi.Type, v any) ABIValue {
+	value := ABIValue{Name: name, Type: t.String(), Value: formatABIValue(t, v)}
+	rv := reflect.ValueOf(v)
+	switch t.T {
+	case abi.TupleTy:
+		for i, elem := range t.TupleElems {
+			value.Components = append(value.Components, newABIValue(t.TupleRawNames[i], *elem, rv.Field(i).Interface()))
+		}
+	case abi.SliceTy, abi.ArrayTy:
+		for i := 0; i < rv.Len(); i++ {
+			value.Components = append(value.Components, newABIValue(fmt.Sprintf("[%d]", i), *t.Elem, rv.Index(i).Interface()))
+		}
+	}
+	return value
+}
+
+// DecodedCall is transaction calldata decoded against an ABI
+type DecodedCall struct {
+	Method string     `json:"method"`
+	Args   []ABIValue `json:"args"`
+}
+
+// decodeCalldata decodes calldata into the called method and its arguments
+func decodeCalldata(contractABI abi.ABI, data []byte) (*DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("calldata too short for a method selector")
+	}
+
+	method, err := contractABI.MethodById(data[:4])
+	if err != nil {
+		return nil, fmt.Errorf("unknown method selector %s", hexutil.Encode(data[:4]))
+	}
+
+	values, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s arguments: %w", method.Name, err)
+	}
+
+	call := &DecodedCall{Method: method.Sig, Args: []ABIValue{}}
+	for i, input := range method.Inputs {
+		call.Args = append(call.Args, newABIValue(input.Name, input.Type, values[i]))
+	}
+	return call, nil
+}
+
+// DecodedEvent is a log decoded against an ABI event
+type DecodedEvent struct {
+	Event string     `json:"event"`
+	Args  []ABIValue `json:"args"`
+}
+
+// decodeLog decodes a log into the ABI event matching its first topic.
+// Indexed arguments are reconstructed from the remaining topics; indexed
+// strings, bytes and arrays are only available as their keccak256 hash.
+func decodeLog(contractABI abi.ABI, l *types.Log) (*DecodedEvent, error) {
+	if len(l.Topics) == 0 {
+		return nil, fmt.Errorf("anonymous log has no event topic")
+	}
+
+	event, err := contractABI.EventByID(l.Topics[0])
+	if err != nil {
+		return nil, fmt.Errorf("unknown event topic %s", l.Topics[0].Hex())
+	}
+
+	nonIndexed, err := event.Inputs.NonIndexed().UnpackValues(l.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s data: %w", event.Name, err)
+	}
+
+	decoded := &DecodedEvent{Event: event.Sig, Args: []ABIValue{}}
+	topics := l.Topics[1:]
+	for _, input := range event.Inputs {
+		var value any
+		if input.Indexed {
+			if len(topics) == 0 {
+				return nil, fmt.Errorf("log has too few topics for %s", event.Sig)
+			}
+			fields := make(map[string]any)
+			if err := abi.ParseTopicsIntoMap(fields, abi.Arguments{input}, topics[:1]); err != nil {
+				return nil, fmt.Errorf("failed to decode %s topic: %w", event.Name, err)
+			}
+			value, topics = fields[input.Name], topics[1:]
+		} else {
+			value, nonIndexed = nonIndexed[0], nonIndexed[1:]
+		}
+
+		decoded.Args = append(decoded.Args, ABIValue{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: formatValue(value),
+		})
+	}
+	if len(topics) > 0 {
+		return nil, fmt.Errorf("log has too many topics for %s", event.Sig)
+	}
+	return decoded, nil
+}
+
+// loadABI reads a JSON ABI from a file, or parses it inline when source is
+// itself JSON. Hardhat and Foundry artifacts, which wrap the ABI in an "abi"
+// field, are accepted as well.
+func loadABI(source string) (abi.ABI, error) {
+	data, name := []byte(source), "inline ABI"
+	if trimmed := strings.TrimSpace(source); !strings.HasPrefix(trimmed, "[") && !strings.HasPrefix(trimmed, "{") {
+		var err error
+		if data, err = os.ReadFile(source); err != nil {
+			return abi.ABI{}, fmt.Errorf("failed to read ABI: %w", err)
+		}
+		name = "ABI " + source
+	}
+
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	if json.Unmarshal(data, &artifact) == nil && len(artifact.ABI) > 0 {
+		data = artifact.ABI
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(data)))
+	if err != nil {
+		return abi.ABI{}, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return parsed, nil
+}
+
+// parseArg converts a command-line value into the Go type the ABI encoder
+// expects for t. Integers may be decimal or 0x-prefixed hex, bytes are hex,
+// and arrays are given as JSON arrays.
+func parseArg(t abi.Type, s string) (any, error) {
+	switch t.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid address %q", s)
+		}
+		return common.HexToAddress(s), nil
+
+	case abi.BoolTy:
+		return strconv.ParseBool(s)
+
+	case abi.StringTy:
+		return s, nil
+
+	case abi.BytesTy:
+		return hexutil.Decode(s)
+
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(s)
+		if err != nil || len(b) > t.Size {
+			return nil, fmt.Errorf("invalid %s value %q", t, s)
+		}
+		v := reflect.New(t.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(common.RightPadBytes(b, t.Size)))
+		return v.Interface(), nil
+
+	case abi.UintTy, abi.IntTy:
+		return parseInt(t, s)
+
+	case abi.SliceTy, abi.ArrayTy:
+		var elems []json.RawMessage
+		if err := json.Unmarshal([]byte(s), &elems); err != nil {
+			return nil, fmt.Errorf("invalid %s value %q: expected a JSON array", t, s)
+		}
+		if t.T == abi.ArrayTy && len(elems) != t.Size {
+			return nil, fmt.Errorf("invalid %s value: expected %d elements, got %d", t, t.Size, len(elems))
+		}
+
+		var v reflect.Value
+		if t.T == abi.SliceTy {
+			v = reflect.MakeSlice(t.GetType(), len(elems), len(elems))
+		} else {
+			v = reflect.New(t.GetType()).Elem()
+		}
+		for i, raw := range elems {
+			var str string
+			if json.Unmarshal(raw, &str) != nil {
+				str = string(raw)
+			}
+			elem, err := parseArg(*t.Elem, str)
+			if err != nil {
+				return nil, err
+			}
+			v.Index(i).Set(reflect.ValueOf(elem))
+		}
+		return v.Interface(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported argument type %s", t)
+}
+
+// parseInt parses an integer argument into *big.Int for sizes above 64 bits,
+// or the exact sized Go integer type the ABI encoder requires otherwise
+func parseInt(t abi.Type, s string) (any, error) {
+	digits, base := s, 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		digits, base = s[2:], 16
+	}
+
+	n, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s value %q", t, s)
+	}
+	if t.T == abi.UintTy && n.Sign() < 0 || n.BitLen() > t.Size {
+		return nil, fmt.Errorf("value %s out of range for %s", s, t)
+	}
+
+	if t.Size > 64 {
+		return n, nil
+	}
+
+	v := reflect.New(t.GetType()).Elem()
+	if t.T == abi.UintTy {
+		v.SetUint(n.Uint64())
+	} else {
+		v.SetInt(n.Int64())
+	}
+	return v.Interface(), nil
+}
+
+// formatValue renders a decoded ABI value for display
+func formatValue(v any) string {
+	switch val := v.(type) {
+	case *big.Int:
+		return val.String()
+	case common.Address:
+		return val.Hex()
+	case common.Hash:
+		return val.Hex()
+	case []byte:
+		return hexutil.Encode(val)
+	case string:
+		return strconv.Quote(val)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		fallthrough
+	case reflect.Slice:
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			parts[i] = formatValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case reflect.Struct:
+		parts := make([]string, rv.NumField())
+		for i := range parts {
+			parts[i] = rv.Type().Field(i).Name + ": " + formatValue(rv.Field(i).Interface())
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	}
+
+	return fmt.Sprint(v)
+}
+
+// formatABIValue formats a decoded value like formatValue, but names tuple
+// fields as in the ABI rather than by their Go struct fields
+func formatABIValue(t abi.Type, v any) string {
+	rv := reflect.ValueOf(v)
+	switch t.T {
+	case abi.TupleTy:
+		parts := make([]string, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			parts[i] = t.TupleRawNames[i] + ": " + formatABIValue(*elem, rv.Field(i).Interface())
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case abi.SliceTy, abi.ArrayTy:
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			parts[i] = formatABIValue(*t.Elem, rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	}
+	return formatValue(v)
+}
+
+// revertData extracts the revert payload returned with an execution error
+func revertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	data, err := hexutil.Decode(hexData)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Selectors of Solidity's built-in revert payloads
+var (
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+)
+
+// panicReasons describes Solidity's panic codes
+var panicReasons = map[uint64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on an empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to an uninitialized function",
+}
+
+// decodeRevert decodes an Error(string) revert reason or a Panic(uint256)
+// code, e.g. "ERC20: insufficient allowance" or "Panic: arithmetic underflow
+// or overflow (0x11)". Other payloads, such as custom errors, are an error.
+func decodeRevert(data []byte) (string, error) {
+	if len(data) < 4 {
+		return "", fmt.Errorf("revert data too short")
+	}
+
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	stringType, _ := abi.NewType("string", "", nil)
+	switch {
+	case bytes.Equal(data[:4], errorSelector):
+		unpacked, err := abi.Arguments{{Type: stringType}}.Unpack(data[4:])
+		if err != nil {
+			return "", fmt.Errorf("invalid Error(string) payload: %w", err)
+		}
+		return unpacked[0].(string), nil
+	case bytes.Equal(data[:4], panicSelector):
+		unpacked, err := abi.Arguments{{Type: uint256Type}}.Unpack(data[4:])
+		if err != nil {
+			return "", fmt.Errorf("invalid Panic(uint256) payload: %w", err)
+		}
+		code := unpacked[0].(*big.Int)
+		reason, ok := panicReasons[code.Uint64()]
+		if !ok || !code.IsUint64() {
+			reason = "unknown panic code"
+		}
+		return fmt.Sprintf("Panic: %s (0x%02x)", reason, code), nil
+	}
+	return "", fmt.Errorf("unrecognized revert selector %s", hexutil.Encode(data[:4]))
+}
+
+// wrapRevert replaces a bare "execution reverted" error with one that
+// includes the decoded revert reason when the node returned one. Custom
+// errors are shown by selector so they can be looked up.
+func wrapRevert(err error) error {
+	data, ok := revertData(err)
+	if !ok || len(data) == 0 {
+		return err
+	}
+	reason, decodeErr := decodeRevert(data)
+	if decodeErr != nil {
+		if len(data) < 4 || bytes.Equal(data[:4], errorSelector) || bytes.Equal(data[:4], panicSelector) {
+			return err
+		}
+		return fmt.Errorf("execution reverted with custom error %s", hexutil.Encode(data[:4]))
+	}
+	return fmt.Errorf("execution reverted: %s", reason)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var (
+	accessListFrom  string
+	accessListTo    string
+	accessListValue string
+	accessListData  string
+)
+
+// CreateAccessList asks the node which addresses and storage slots a
+// transaction touches (eth_createAccessList) and returns them with the gas the
+// transaction uses when sent with that list. ethclient has no wrapper for the
+// method, so it is called through the raw RPC client.
+func (c *Client) CreateAccessList(from common.Address, to *common.Address, value *big.Int, data []byte) (types.AccessList, uint64, error) {
+	arg := map[string]any{"from": from}
+	if to != nil {
+		arg["to"] = to
+	}
+	if value != nil && value.Sign() > 0 {
+		arg["value"] = (*hexutil.Big)(value)
+	}
+	if len(data) > 0 {
+		arg["input"] = hexutil.Bytes(data)
+	}
+
+	var result struct {
+		AccessList types.AccessList `json:"accessList"`
+		GasUsed    hexutil.Uint64   `json:"gasUsed"`
+		Error      string           `json:"error"`
+	}
+	err := c.withRetry(func(ec *ethclient.Client) error {
+		return ec.Client().CallContext(c.ctx, &result, "eth_createAccessList", arg, "latest")
+	})
+	if err != nil && isMethodNotFound(err) {
+		return nil, 0, fmt.Errorf("eth_createAccessList: %w", errMethodUnsupported)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create access list: %w", wrapRevert(err))
+	}
+	if result.Error != "" {
+		return nil, 0, fmt.Errorf("failed to create access list: %s", result.Error)
+	}
+	if result.AccessList == nil {
+		result.AccessList = types.AccessList{}
+	}
+	return result.AccessList, uint64(result.GasUsed), nil
+}
+
+// loadAccessList reads an access list from a JSON file holding either the
+// list itself or the access-list command's JSON output
+func loadAccessList(path string) (types.AccessList, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read access list: %w", err)
+	}
+
+	var list types.AccessList
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+		var result AccessListResult
+		err = json.Unmarshal(data, &result)
+		list = result.AccessList
+	} else {
+		err = json.Unmarshal(data, &list)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse access list %s: %w", path, err)
+	}
+	return list, nil
+}
+
+// AccessListResult is the result of the access-list command. GasWithoutList
+// is the plain estimate, for comparison.
+type AccessListResult struct {
+	AccessList     types.AccessList `json:"accessList"`
+	GasUsed        uint64           `json:"gasUsed"`
+	GasWithoutList uint64           `json:"gasWithoutList,omitempty"`
+}
+
+func (r AccessListResult) renderText(w io.Writer) {
+	if len(r.AccessList) == 0 {
+		fmt.Fprintln(w, "Access List: (empty)")
+	}
+	for _, tuple := range r.AccessList {
+		printField(w, "Address", tuple.Address.Hex())
+		for _, key := range tuple.StorageKeys {
+			fmt.Fprintf(w, "  %s\n", key.Hex())
+		}
+	}
+	printField(w, "Gas With Access List", r.GasUsed)
+	if r.GasWithoutList > 0 {
+		printField(w, "Gas Without Access List", r.GasWithoutList)
+	}
+}
+
+var accessListCmd = &cobra.Command{
+	Use:   "access-list",
+	Short: "Generate an EIP-2930 access list for a transaction",
+	Long: `Calls eth_createAccessList for a transaction from --from to --to with
+optional --value and --data, and prints the addresses and storage slots it
+touches together with the gas used with and without the list. Save the JSON
+output (-o json) and pass it to send --access-list to include the list.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		from, err := rpcClient.Resolve(accessListFrom)
+		if err != nil {
+			fatal(err)
+		}
+
+		var to *common.Address
+		if accessListTo != "" {
+			addr, err := rpcClient.Resolve(accessListTo)
+			if err != nil {
+				fatal(err)
+			}
+			to = &addr
+		}
+
+		value := new(big.Int)
+		if accessListValue != "" {
+			if value, err = parseUnits(accessListValue, 18); err != nil {
+				fatal(err)
+			}
+		}
+
+		var data []byte
+		if accessListData != "" {
+			if data, err = hexutil.Decode(accessListData); err != nil {
+				fatalf("invalid --data: %v", err)
+			}
+		}
+
+		list, gas, err := rpcClient.CreateAccessList(from, to, value, data)
+		if err != nil {
+			fatal(err)
+		}
+
+		toHex := ""
+		if to != nil {
+			toHex = to.Hex()
+		}
+		result := AccessListResult{AccessList: list, GasUsed: gas}
+		if plain, err := rpcClient.EstimateGas(from.Hex(), toHex, value, data); err == nil {
+			result.GasWithoutList = plain
+		}
+		render(result)
+	},
+}
+
+func init() {
+	accessListCmd.Flags().StringVar(&accessListFrom, "from", "", "Sender address or ENS name")
+	accessListCmd.Flags().StringVar(&accessListTo, "to", "", "Recipient address or ENS name (omit for contract creation)")
+	accessListCmd.Flags().StringVar(&accessListValue, "value", "", "Value to send in ETH, e.g. 0.1")
+	accessListCmd.Flags().StringVar(&accessListData, "data", "", "Hex-encoded calldata")
+	accessListCmd.MarkFlagRequired("from")
+
+	rootCmd.AddCommand(accessListCmd)
+}
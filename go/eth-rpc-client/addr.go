@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+// Checksum states reported by the addr command
+const (
+	checksumValid   = "valid"
+	checksumInvalid = "invalid"
+	checksumMissing = "missing"
+	checksumNA      = "n/a"
+)
+
+// checkAddress validates a hex address and reports the state of its EIP-55
+// checksum: missing for all-lowercase or all-uppercase input, valid or
+// invalid for mixed case. Malformed input is reported with what is wrong.
+func checkAddress(s string) (common.Address, string, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	for i, r := range digits {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return common.Address{}, "", fmt.Errorf("invalid address %q: non-hex character %q at position %d", s, r, i+1)
+		}
+	}
+	if len(digits) != 2*common.AddressLength {
+		return common.Address{}, "", fmt.Errorf("invalid address %q: expected %d hex characters, got %d", s, 2*common.AddressLength, len(digits))
+	}
+
+	addr := common.HexToAddress(digits)
+	switch {
+	case digits == strings.ToLower(digits) || digits == strings.ToUpper(digits):
+		// Addresses without letters have no case to check
+		if strings.ContainsAny(digits, "abcdefABCDEF") {
+			return addr, checksumMissing, nil
+		}
+		return addr, checksumValid, nil
+	case digits == addr.Hex()[2:]:
+		return addr, checksumValid, nil
+	}
+	return addr, checksumInvalid, nil
+}
+
+// AddressInfo is the result of the addr command. Checksum is n/a for ENS
+// names and aliases; Type is "contract" or "eoa".
+type AddressInfo struct {
+	Input    string `json:"input"`
+	Address  string `json:"address"`
+	Checksum string `json:"checksum"`
+	Type     string `json:"type"`
+}
+
+func (a AddressInfo) renderText(w io.Writer) {
+	printField(w, "Address", a.Address)
+	switch a.Checksum {
+	case checksumInvalid:
+		fmt.Fprintf(w, "%s %s\n", cyan("Checksum:"), red("invalid (the input is probably mistyped)"))
+	case checksumMissing:
+		printField(w, "Checksum", "missing (input is not mixed-case)")
+	default:
+		printField(w, "Checksum", a.Checksum)
+	}
+	if a.Type == "contract" {
+		printField(w, "Type", "contract")
+	} else {
+		printField(w, "Type", "EOA (no code)")
+	}
+}
+
+var addrCmd = &cobra.Command{
+	Use:   "addr [address|ens-name]",
+	Short: "Validate an address and show its checksummed form",
+	Long: `Prints the EIP-55 checksummed form of an address, whether the checksum in
+the input was valid, and whether the address is a contract or an EOA. Input of
+the wrong length or with non-hex characters is rejected, and the command exits
+non-zero when a mixed-case address fails its checksum, which usually means it
+was mistyped. ENS names and address-book aliases are resolved first.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		input := args[0]
+		info := AddressInfo{Input: input, Checksum: checksumNA}
+
+		var addr common.Address
+		if _, ok := lookupAlias(input); ok || isENSName(input) {
+			resolved, err := rpcClient.Resolve(input)
+			if err != nil {
+				fatal(err)
+			}
+			addr = resolved
+		} else {
+			checked, checksum, err := checkAddress(input)
+			if err != nil {
+				fatal(err)
+			}
+			addr, info.Checksum = checked, checksum
+		}
+		info.Address = addr.Hex()
+
+		code, err := rpcClient.GetCode(info.Address, nil)
+		if err != nil {
+			fatal(err)
+		}
+		info.Type = "eoa"
+		if len(code) > 0 {
+			info.Type = "contract"
+		}
+
+		render(info)
+		if info.Checksum == checksumInvalid {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(addrCmd)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// isStateUnavailable reports whether an error means the node has pruned the
+// state of the requested block, i.e. an archive node is needed
+func isStateUnavailable(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "missing trie node") ||
+		strings.Contains(msg, "historical state") ||
+		strings.Contains(msg, "state not available") ||
+		strings.Contains(msg, "state is not available") ||
+		strings.Contains(msg, "pruned")
+}
+
+// BalanceDiff is the result of the balance-diff command. Amounts are in wei;
+// Difference is signed.
+type BalanceDiff struct {
+	Address    string `json:"address"`
+	FromBlock  string `json:"fromBlock"`
+	ToBlock    string `json:"toBlock"`
+	FromWei    string `json:"fromWei"`
+	ToWei      string `json:"toWei"`
+	Difference string `json:"difference"`
+}
+
+func (d BalanceDiff) renderText(w io.Writer) {
+	printField(w, "Balance at "+d.FromBlock, formatAmount(parseWei(d.FromWei), amountUnit))
+	printField(w, "Balance at "+d.ToBlock, formatAmount(parseWei(d.ToWei), amountUnit))
+
+	diff := parseWei(d.Difference)
+	formatted := formatAmount(diff, amountUnit)
+	if diff.Sign() > 0 {
+		formatted = "+" + formatted
+	}
+	printField(w, "Difference", formatted)
+}
+
+var balanceDiffCmd = &cobra.Command{
+	Use:   "balance-diff [address|ens-name] [from-block] [to-block]",
+	Short: "Show how an address's balance changed between two blocks",
+	Long: `Reads the address's balance at two blocks (numbers or tags) and prints both
+with the signed difference. Reading state of old blocks requires an archive node.`,
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		addr, err := rpcClient.Resolve(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		blocks := args[1:]
+		balances := make([]*big.Int, len(blocks))
+		for i, arg := range blocks {
+			block, err := parseBlockTag(arg)
+			if err != nil {
+				fatal(err)
+			}
+			balances[i], err = rpcClient.GetBalanceAt(addr.Hex(), block)
+			if err != nil && isStateUnavailable(err) {
+				fatalf("state at block %s is not available on this node; historical balances require an archive node (%v)", arg, err)
+			}
+			if err != nil {
+				fatal(err)
+			}
+		}
+
+		render(BalanceDiff{
+			Address:    addr.Hex(),
+			FromBlock:  blocks[0],
+			ToBlock:    blocks[1],
+			FromWei:    balances[0].String(),
+			ToWei:      balances[1].String(),
+			Difference: new(big.Int).Sub(balances[1], balances[0]).String(),
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(balanceDiffCmd)
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	balancesFile        string
+	balancesConcurrency int
+)
+
+// BalanceLookup is the outcome of looking up one address in a batch
+type BalanceLookup struct {
+	Input   string
+	Balance *big.Int
+	Err     error
+}
+
+// GetBalances looks up the ETH balance of every address. Hex addresses and
+// aliases are batched through Multicall3; otherwise, or when multicall is
+// unavailable, lookups run concurrently using at most concurrency workers.
+// Results are returned in input order, and a failed lookup is recorded in its
+// entry instead of aborting the batch.
+func (c *Client) GetBalances(addresses []string, concurrency int) []BalanceLookup {
+	if results, ok := c.getBalancesMulticall(addresses); ok {
+		return results
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]BalanceLookup, len(addresses))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				balance, err := c.GetBalance(addresses[i])
+				results[i] = BalanceLookup{Input: addresses[i], Balance: balance, Err: err}
+			}
+		}()
+	}
+
+	for i := range addresses {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// readAddressFile reads addresses from a file, one per line. Blank lines and
+// lines starting with # are skipped.
+func readAddressFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open address file: %w", err)
+	}
+	defer f.Close()
+
+	var addresses []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		addresses = append(addresses, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read address file: %w", err)
+	}
+	return addresses, nil
+}
+
+// BalanceEntry is a single address's result in the balances command
+type BalanceEntry struct {
+	Address string `json:"address"`
+	Wei     string `json:"wei,omitempty"`
+	Ether   string `json:"ether,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BalanceList is the result of the balances command
+type BalanceList []BalanceEntry
+
+func (b BalanceList) renderText(w io.Writer) {
+	for _, entry := range b {
+		if entry.Error != "" {
+			fmt.Fprintf(w, "%s %s\n", cyan(entry.Address+":"), red(entry.Error))
+			continue
+		}
+		fmt.Fprintf(w, "%s %s\n", cyan(entry.Address+":"), green(formatAmount(parseWei(entry.Wei), amountUnit)))
+	}
+}
+
+// renderCSV writes one address,balance_wei,balance_eth,error row per entry.
+// Wei values are exact integers so spreadsheets do not round them.
+func (b BalanceList) renderCSV(w *csv.Writer) {
+	w.Write([]string{"address", "balance_wei", "balance_eth", "error"})
+	for _, entry := range b {
+		w.Write([]string{entry.Address, entry.Wei, entry.Ether, entry.Error})
+	}
+}
+
+var balancesCmd = &cobra.Command{
+	Use:   "balances [address|ens-name...]",
+	Short: "Get ETH balances for many addresses concurrently",
+	Long: `Looks up the ETH balance of every address given as an argument or listed in
+--file (one per line), using a bounded pool of --concurrency workers. Results are
+printed in input order. Failed lookups are reported per address and make the
+command exit non-zero once all lookups have finished. Supports --output csv.`,
+	Annotations: map[string]string{annotationCSV: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		addresses := args
+		if balancesFile != "" {
+			fromFile, err := readAddressFile(balancesFile)
+			if err != nil {
+				fatal(err)
+			}
+			addresses = append(addresses, fromFile...)
+		}
+		if len(addresses) == 0 {
+			fatal("no addresses given")
+		}
+
+		failed := false
+		list := make(BalanceList, 0, len(addresses))
+		for _, lookup := range rpcClient.GetBalances(addresses, balancesConcurrency) {
+			if lookup.Err != nil {
+				failed = true
+				list = append(list, BalanceEntry{Address: lookup.Input, Error: lookup.Err.Error()})
+				continue
+			}
+			list = append(list, BalanceEntry{
+				Address: lookup.Input,
+				Wei:     lookup.Balance.String(),
+				Ether:   formatEther(lookup.Balance),
+			})
+		}
+
+		render(list)
+		if failed {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	balancesCmd.Flags().StringVar(&balancesFile, "file", "", "File with one address per line")
+	balancesCmd.Flags().IntVar(&balancesConcurrency, "concurrency", 10, "Maximum number of concurrent lookups")
+
+	rootCmd.AddCommand(balancesCmd)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var balanceWatchInterval time.Duration
+
+// PollBalance polls an address's balance every interval and calls fn with
+// the first balance and then with every balance that differs from the last
+// one. It returns nil once the client's context is cancelled.
+func (c *Client) PollBalance(addr common.Address, interval time.Duration, fn func(balance, previous *big.Int)) error {
+	var previous *big.Int
+	for ; ; c.sleep(interval) {
+		var balance *big.Int
+		err := c.withRetry(func(ec *ethclient.Client) (err error) {
+			balance, err = ec.BalanceAt(c.ctx, addr, nil)
+			return err
+		})
+		if c.ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get balance: %w", err)
+		}
+
+		if previous == nil || balance.Cmp(previous) != 0 {
+			fn(balance, previous)
+			previous = balance
+		}
+	}
+}
+
+// BalanceChange is printed by the balance-watch command for the initial
+// balance and each change. Amounts are in wei; Delta is signed.
+type BalanceChange struct {
+	Time    string `json:"time"`
+	Address string `json:"address"`
+	Wei     string `json:"wei"`
+	Delta   string `json:"delta,omitempty"`
+}
+
+func (b BalanceChange) renderText(w io.Writer) {
+	line := fmt.Sprintf("%s Balance: %s", cyan("["+b.Time+"]"), green(formatAmount(parseWei(b.Wei), amountUnit)))
+	if b.Delta != "" {
+		delta := parseWei(b.Delta)
+		if delta.Sign() > 0 {
+			line += " " + green("+"+formatAmount(delta, amountUnit))
+		} else {
+			line += " " + red(formatAmount(delta, amountUnit))
+		}
+	}
+	fmt.Fprintln(w, line)
+}
+
+var balanceWatchCmd = &cobra.Command{
+	Use:   "balance-watch [address|ens-name]",
+	Short: "Poll an address and print its balance whenever it changes",
+	Long: `Prints the address's current balance, then polls it every --interval and
+prints a timestamped line with the new balance and the change whenever it
+differs. Works over plain HTTP endpoints; press Ctrl-C to stop.`,
+	Annotations: map[string]string{annotationLongRunning: "", annotationStreaming: ""},
+	Args:        cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		addr, err := rpcClient.Resolve(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		err = rpcClient.PollBalance(addr, balanceWatchInterval, func(balance, previous *big.Int) {
+			change := BalanceChange{
+				Time:    displayTime(time.Now()).Format(time.RFC3339),
+				Address: addr.Hex(),
+				Wei:     balance.String(),
+			}
+			if previous != nil {
+				change.Delta = new(big.Int).Sub(balance, previous).String()
+			}
+			renderEvent(change)
+		})
+		if err != nil {
+			fatal(err)
+		}
+	},
+}
+
+func init() {
+	balanceWatchCmd.Flags().DurationVar(&balanceWatchInterval, "interval", 12*time.Second, "Polling interval")
+
+	rootCmd.AddCommand(balanceWatchCmd)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var baseFeeBlocks uint64
+
+// sparkTicks are the bar characters of a sparkline, lowest to highest
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// BaseFeeSample is the base fee of one block, in wei
+type BaseFeeSample struct {
+	Block   uint64 `json:"block"`
+	BaseFee string `json:"baseFee"`
+}
+
+// GetBaseFees returns the base fees of the last n blocks, oldest first. It
+// uses eth_feeHistory and falls back to reading the headers in batches when
+// the node does not support it. Pre-London blocks have no base fee; they are left out
+// and counted in skipped.
+func (c *Client) GetBaseFees(n uint64) (samples []BaseFeeSample, skipped int, err error) {
+	history, err := c.FeeHistory(n, []float64{})
+	if err == nil {
+		oldest := history.OldestBlock.Uint64()
+		// The last entry is the projected base fee of the next block
+		for i, fee := range history.BaseFee[:max(len(history.BaseFee)-1, 0)] {
+			if fee == nil || fee.Sign() == 0 {
+				skipped++
+				continue
+			}
+			samples = append(samples, BaseFeeSample{Block: oldest + uint64(i), BaseFee: fee.String()})
+		}
+		return samples, skipped, nil
+	}
+	if !isMethodNotFound(err) {
+		return nil, 0, err
+	}
+
+	head, err := c.GetBlockNumber()
+	if err != nil {
+		return nil, 0, err
+	}
+	var numbers []uint64
+	for number := head - min(n-1, head); number <= head; number++ {
+		numbers = append(numbers, number)
+	}
+	headers, errs, err := c.GetHeaders(numbers)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get headers: %w", err)
+	}
+	for i, header := range headers {
+		if errs != nil && errs[i] != nil {
+			return nil, 0, fmt.Errorf("failed to get header of block %d: %w", numbers[i], errs[i])
+		}
+		if header.BaseFee == nil {
+			skipped++
+			continue
+		}
+		samples = append(samples, BaseFeeSample{Block: numbers[i], BaseFee: header.BaseFee.String()})
+	}
+	return samples, skipped, nil
+}
+
+// BaseFeeTrend is the result of the basefee command. Amounts are in wei.
+type BaseFeeTrend struct {
+	Samples []BaseFeeSample `json:"samples"`
+	Min     string          `json:"min"`
+	Max     string          `json:"max"`
+	Average string          `json:"average"`
+	Trend   string          `json:"trend"`
+	Skipped int             `json:"skippedPreLondon,omitempty"`
+}
+
+// newBaseFeeTrend summarizes base fee samples. The trend compares the first
+// and last sample and counts as flat within 1%.
+func newBaseFeeTrend(samples []BaseFeeSample, skipped int) BaseFeeTrend {
+	minFee, maxFee, sum := parseWei(samples[0].BaseFee), parseWei(samples[0].BaseFee), new(big.Int)
+	for _, s := range samples {
+		fee := parseWei(s.BaseFee)
+		if fee.Cmp(minFee) < 0 {
+			minFee = fee
+		}
+		if fee.Cmp(maxFee) > 0 {
+			maxFee = fee
+		}
+		sum.Add(sum, fee)
+	}
+
+	first, last := parseWei(samples[0].BaseFee), parseWei(samples[len(samples)-1].BaseFee)
+	tolerance := new(big.Int).Div(first, big.NewInt(100))
+	diff := new(big.Int).Sub(last, first)
+	trend := "flat"
+	switch {
+	case diff.Cmp(tolerance) > 0:
+		trend = "rising"
+	case diff.Cmp(new(big.Int).Neg(tolerance)) < 0:
+		trend = "falling"
+	}
+
+	return BaseFeeTrend{
+		Samples: samples,
+		Min:     minFee.String(),
+		Max:     maxFee.String(),
+		Average: sum.Div(sum, big.NewInt(int64(len(samples)))).String(),
+		Trend:   trend,
+		Skipped: skipped,
+	}
+}
+
+// sparkline draws one bar per sample scaled between the minimum and maximum
+func (t BaseFeeTrend) sparkline() string {
+	minFee, maxFee := parseWei(t.Min), parseWei(t.Max)
+	span := new(big.Int).Sub(maxFee, minFee)
+
+	var b strings.Builder
+	for _, s := range t.Samples {
+		tick := 0
+		if span.Sign() > 0 {
+			level := new(big.Int).Sub(parseWei(s.BaseFee), minFee)
+			level.Mul(level, big.NewInt(int64(len(sparkTicks)-1)))
+			tick = int(level.Div(level, span).Int64())
+		}
+		b.WriteRune(sparkTicks[tick])
+	}
+	return b.String()
+}
+
+func (t BaseFeeTrend) renderText(w io.Writer) {
+	unit := gasPriceUnit()
+	first, last := t.Samples[0], t.Samples[len(t.Samples)-1]
+
+	printField(w, "Blocks", fmt.Sprintf("#%d to #%d", first.Block, last.Block))
+	fmt.Fprintf(w, "%s %s\n", cyan("Base Fee:"), t.sparkline())
+	printField(w, "Trend", fmt.Sprintf("%s (%s -> %s)", t.Trend,
+		formatAmount(parseWei(first.BaseFee), unit), formatAmount(parseWei(last.BaseFee), unit)))
+	printField(w, "Min", formatAmount(parseWei(t.Min), unit))
+	printField(w, "Max", formatAmount(parseWei(t.Max), unit))
+	printField(w, "Average", formatAmount(parseWei(t.Average), unit))
+}
+
+var baseFeeCmd = &cobra.Command{
+	Use:   "basefee",
+	Short: "Show the base fee trend over recent blocks",
+	Long: `Fetches the base fee of the last --blocks blocks and prints a sparkline, the
+trend from the first to the last block and the min, max and average. Uses
+eth_feeHistory when available and reads each header otherwise. Blocks from
+before the London upgrade have no base fee and are skipped.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if baseFeeBlocks == 0 {
+			fatal("--blocks must be at least 1")
+		}
+
+		samples, skipped, err := rpcClient.GetBaseFees(baseFeeBlocks)
+		if err != nil {
+			fatal(err)
+		}
+		if skipped > 0 {
+			fmt.Fprintf(progressWriter(), "Note: skipped %d pre-London blocks without a base fee\n", skipped)
+		}
+		if len(samples) == 0 {
+			fatal("no blocks with a base fee in range; the chain may not support EIP-1559")
+		}
+
+		render(newBaseFeeTrend(samples, skipped))
+	},
+}
+
+func init() {
+	baseFeeCmd.Flags().Uint64Var(&baseFeeBlocks, "blocks", 20, "Number of recent blocks to include")
+
+	rootCmd.AddCommand(baseFeeCmd)
+}
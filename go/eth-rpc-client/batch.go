@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxBatchSize is the number of calls sent in one JSON-RPC batch. Larger
+// batches are split, as many providers reject batches of more than 100.
+const maxBatchSize = 100
+
+// BatchCall sends the calls as JSON-RPC batches, one HTTP request per
+// maxBatchSize calls, which most providers count as a single request against
+// their rate limits. A batch that fails as a whole, e.g. because the
+// connection dropped, is retried and failed over like a single call and its
+// error returned; errors of individual calls are left in each element's
+// Error field for the caller to inspect.
+func (c *Client) BatchCall(elems []rpc.BatchElem) error {
+	for start := 0; start < len(elems); start += maxBatchSize {
+		batch := elems[start:min(start+maxBatchSize, len(elems))]
+		err := c.withRetry(func(ec *ethclient.Client) error {
+			return ec.Client().BatchCallContext(c.ctx, batch)
+		})
+		if err != nil {
+			return fmt.Errorf("batch request failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetReceipts fetches the receipts of many transactions in batches. errs
+// holds the error of each receipt that could not be fetched, with
+// ethereum.NotFound for unknown or pending transactions.
+func (c *Client) GetReceipts(hashes []common.Hash) (receipts []*types.Receipt, errs []error, err error) {
+	receipts = make([]*types.Receipt, len(hashes))
+	elems := make([]rpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		elems[i] = rpc.BatchElem{Method: "eth_getTransactionReceipt", Args: []any{hash}, Result: &receipts[i]}
+	}
+	if err := c.BatchCall(elems); err != nil {
+		return nil, nil, err
+	}
+	return receipts, batchErrors(elems, func(i int) bool { return receipts[i] == nil }), nil
+}
+
+// GetHeaders fetches the headers of many blocks in batches. errs holds the
+// error of each header that could not be fetched, with ethereum.NotFound for
+// blocks the node does not have.
+func (c *Client) GetHeaders(numbers []uint64) (headers []*types.Header, errs []error, err error) {
+	headers = make([]*types.Header, len(numbers))
+	elems := make([]rpc.BatchElem, len(numbers))
+	for i, number := range numbers {
+		elems[i] = rpc.BatchElem{Method: "eth_getBlockByNumber", Args: []any{hexutil.EncodeUint64(number), false}, Result: &headers[i]}
+	}
+	if err := c.BatchCall(elems); err != nil {
+		return nil, nil, err
+	}
+	return headers, batchErrors(elems, func(i int) bool { return headers[i] == nil }), nil
+}
+
+// batchErrors collects the per-call errors of a batch, reporting calls that
+// returned null as ethereum.NotFound. It returns nil when every call succeeded.
+func batchErrors(elems []rpc.BatchElem, isNull func(i int) bool) []error {
+	var errs []error
+	for i, elem := range elems {
+		err := elem.Error
+		if err == nil && isNull(i) {
+			err = ethereum.NotFound
+		}
+		if err == nil {
+			continue
+		}
+		if errs == nil {
+			errs = make([]error, len(elems))
+		}
+		errs[i] = err
+	}
+	return errs
+}
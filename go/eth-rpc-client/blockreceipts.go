@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/cobra"
+)
+
+// GetBlockReceipts returns the receipts of every transaction in a block using
+// eth_getBlockReceipts. When the node does not support it, the receipts are
+// fetched with batched eth_getTransactionReceipt calls and batched is false.
+func (c *Client) GetBlockReceipts(number *big.Int) (receipts []*types.Receipt, batched bool, err error) {
+	blockNr := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(number.Int64()))
+	err = c.withRetry(func(ec *ethclient.Client) error {
+		return ec.Client().CallContext(c.ctx, &receipts, "eth_getBlockReceipts", blockNr)
+	})
+	if err == nil {
+		return receipts, true, nil
+	}
+	if !isMethodNotFound(err) {
+		return nil, false, fmt.Errorf("failed to get block receipts: %w", err)
+	}
+
+	block, err := c.GetBlockAt(number)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hashes := make([]common.Hash, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		hashes[i] = tx.Hash()
+	}
+	receipts, errs, err := c.GetReceipts(hashes)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get receipts: %w", err)
+	}
+	for i, err := range errs {
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get receipt for %s: %w", hashes[i].Hex(), err)
+		}
+	}
+	return receipts, false, nil
+}
+
+// BlockReceipt is one row of the block-receipts command
+type BlockReceipt struct {
+	TxHash  string `json:"txHash"`
+	Status  string `json:"status"`
+	GasUsed uint64 `json:"gasUsed"`
+}
+
+// BlockReceipts is the result of the block-receipts command
+type BlockReceipts []BlockReceipt
+
+func (b BlockReceipts) renderText(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, cyan("TX HASH")+"\t"+cyan("STATUS")+"\t"+cyan("GAS USED"))
+	for _, r := range b {
+		fmt.Fprintf(tw, "%s\t%s\t%d\n", r.TxHash, statusString(r.Status), r.GasUsed)
+	}
+	tw.Flush()
+}
+
+var blockReceiptsCmd = &cobra.Command{
+	Use:   "block-receipts [number|tag]",
+	Short: "List the receipts of every transaction in a block",
+	Long: `Fetches all receipts of a block in one eth_getBlockReceipts call. Nodes that
+do not support it fall back to eth_getTransactionReceipt calls for each
+transaction, sent in JSON-RPC batches of up to 100.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		number, err := parseBlockTag(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		receipts, batched, err := rpcClient.GetBlockReceipts(number)
+		if err != nil {
+			fatal(err)
+		}
+		if !batched {
+			fmt.Fprintln(progressWriter(), "Note: node does not support eth_getBlockReceipts, fetched receipts in batches (slower path)")
+		}
+
+		rows := make(BlockReceipts, 0, len(receipts))
+		for _, receipt := range receipts {
+			rows = append(rows, BlockReceipt{
+				TxHash:  receipt.TxHash.Hex(),
+				Status:  receiptStatus(receipt.Status),
+				GasUsed: receipt.GasUsed,
+			})
+		}
+
+		render(rows)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(blockReceiptsCmd)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// blockTags maps the named block tags to go-ethereum's sentinel block numbers
+var blockTags = map[string]rpc.BlockNumber{
+	"latest":    rpc.LatestBlockNumber,
+	"pending":   rpc.PendingBlockNumber,
+	"safe":      rpc.SafeBlockNumber,
+	"finalized": rpc.FinalizedBlockNumber,
+	"earliest":  rpc.EarliestBlockNumber,
+}
+
+// parseBlockTag parses a decimal or 0x-prefixed hex block number, or one of
+// the tags latest, pending, safe, finalized and earliest. Tags are returned
+// as the negative sentinel numbers ethclient translates back into tags.
+func parseBlockTag(s string) (*big.Int, error) {
+	if tag, ok := blockTags[strings.ToLower(s)]; ok {
+		return big.NewInt(int64(tag)), nil
+	}
+
+	// Base 0 would also accept octal ("010" is 8) and binary, which
+	// nobody means when typing a block number
+	digits, base := s, 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		digits, base = s[2:], 16
+	}
+	n, err := strconv.ParseUint(digits, base, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block %q (expected a decimal or 0x hex number, or latest, pending, safe, finalized, earliest)", s)
+	}
+	return new(big.Int).SetUint64(n), nil
+}
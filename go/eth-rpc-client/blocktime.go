@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var blockTimeSamples uint64
+
+// GetHeaderAt returns the header of a block number or tag (see
+// parseBlockTag), or of the latest block when nil
+func (c *Client) GetHeaderAt(number *big.Int) (*types.Header, error) {
+	var header *types.Header
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		header, err = ec.HeaderByNumber(c.ctx, number)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header: %w", err)
+	}
+	return header, nil
+}
+
+// BlockTime is the result of the blocktime command
+type BlockTime struct {
+	FromBlock      uint64  `json:"fromBlock"`
+	ToBlock        uint64  `json:"toBlock"`
+	Blocks         uint64  `json:"blocks"`
+	ElapsedSeconds int64   `json:"elapsedSeconds"`
+	AverageSeconds float64 `json:"averageSeconds"`
+	Warning        string  `json:"warning,omitempty"`
+}
+
+func (b BlockTime) renderText(w io.Writer) {
+	printField(w, "Blocks", fmt.Sprintf("%d (#%d to #%d)", b.Blocks, b.FromBlock, b.ToBlock))
+	printField(w, "Elapsed", fmt.Sprintf("%ds", b.ElapsedSeconds))
+	if b.Warning != "" {
+		fmt.Fprintln(w, red("Warning: "+b.Warning))
+		return
+	}
+	printField(w, "Average Block Time", fmt.Sprintf("%.2fs", b.AverageSeconds))
+}
+
+// GetBlockTime measures the average seconds per block over the last samples
+// blocks. A window that is empty or whose timestamps go backwards has no
+// meaningful average; it is reported through BlockTime.Warning instead.
+func (c *Client) GetBlockTime(samples uint64) (*BlockTime, error) {
+	latest, err := c.GetHeaderAt(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	head := latest.Number.Uint64()
+	from := head - min(samples, head)
+	earlier, err := c.GetHeaderAt(new(big.Int).SetUint64(from))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BlockTime{
+		FromBlock:      from,
+		ToBlock:        head,
+		Blocks:         head - from,
+		ElapsedSeconds: int64(latest.Time) - int64(earlier.Time),
+	}
+	switch {
+	case result.Blocks == 0:
+		result.Warning = "the chain has no blocks to measure yet"
+	case result.ElapsedSeconds < 0:
+		result.Warning = fmt.Sprintf("timestamps are not monotonic (block %d is older than block %d)", head, from)
+	default:
+		result.AverageSeconds = float64(result.ElapsedSeconds) / float64(result.Blocks)
+	}
+	return result, nil
+}
+
+var blockTimeCmd = &cobra.Command{
+	Use:   "blocktime",
+	Short: "Show the average block time over recent blocks",
+	Long: `Compares the timestamps of the latest block and the block --samples blocks
+before it and reports the average seconds per block over that window.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if blockTimeSamples == 0 {
+			fatal("--samples must be at least 1")
+		}
+
+		result, err := rpcClient.GetBlockTime(blockTimeSamples)
+		if err != nil {
+			fatal(err)
+		}
+		render(result)
+	},
+}
+
+func init() {
+	blockTimeCmd.Flags().Uint64Var(&blockTimeSamples, "samples", 100, "Number of blocks to average over")
+
+	rootCmd.AddCommand(blockTimeCmd)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var (
+	breakerThreshold int
+	breakerWindow    time.Duration
+	breakerCooldown  time.Duration
+)
+
+// errCircuitOpen is returned when every endpoint's circuit breaker is open
+var errCircuitOpen = errors.New("every RPC endpoint is failing; skipping them until their circuit breaker cool-down (--breaker-cooldown) ends")
+
+// Circuit breaker states
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half-open"
+)
+
+// circuitBreaker tracks the health of one endpoint. After threshold
+// consecutive transient failures within window it opens and the endpoint is
+// skipped for cooldown; it then half-opens to let a single call probe the
+// endpoint, closing again on success and reopening on failure. A nil breaker
+// always allows calls.
+type circuitBreaker struct {
+	label     string
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+
+	mu           sync.Mutex
+	state        string
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	probing      bool
+}
+
+// newCircuitBreaker returns a breaker for the endpoint at rawURL configured by
+// the --breaker-* flags, or nil when --breaker-threshold is 0
+func newCircuitBreaker(rawURL string) (*circuitBreaker, error) {
+	if breakerThreshold < 0 {
+		return nil, fmt.Errorf("invalid --breaker-threshold %d (must not be negative)", breakerThreshold)
+	}
+	if breakerThreshold == 0 {
+		return nil, nil
+	}
+	return &circuitBreaker{
+		label:     endpointLabel(rawURL),
+		threshold: breakerThreshold,
+		window:    breakerWindow,
+		cooldown:  breakerCooldown,
+		state:     breakerClosed,
+	}, nil
+}
+
+// endpointLabel names an endpoint in log messages by its host only, since
+// provider URLs often carry an API key in the path or query
+func endpointLabel(rawURL string) string {
+	if isIPCEndpoint(rawURL) {
+		return dialTarget(rawURL)
+	}
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return "endpoint"
+}
+
+// allow reports whether a call may be sent to the endpoint. Once the
+// cool-down has passed, the first caller is let through as the half-open probe.
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.transition(breakerHalfOpen)
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+	}
+	return true
+}
+
+// record updates the breaker with the outcome of a call. Only transient
+// failures count: an endpoint that answers with a JSON-RPC error is up.
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil || !isTransient(err) {
+		b.failures = 0
+		b.probing = false
+		if b.state != breakerClosed {
+			b.transition(breakerClosed)
+		}
+		return
+	}
+
+	if b.state == breakerHalfOpen {
+		b.probing = false
+		b.openedAt = time.Now()
+		b.transition(breakerOpen)
+		return
+	}
+	if b.failures == 0 || time.Since(b.firstFailure) > b.window {
+		b.failures, b.firstFailure = 0, time.Now()
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.failures = 0
+		b.openedAt = time.Now()
+		b.transition(breakerOpen)
+	}
+}
+
+// transition changes the breaker's state and logs the change with --verbose
+func (b *circuitBreaker) transition(state string) {
+	logVerbose("circuit breaker for %s: %s -> %s", b.label, b.state, state)
+	b.state = state
+}
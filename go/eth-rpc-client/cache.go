@@ -0,0 +1,303 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	cacheDir    string
+	cacheSizeMB int64
+	noCache     bool
+)
+
+// diskCache stores responses for finalized blocks, receipts and transactions
+// as one file per key. When the directory grows beyond maxBytes the least
+// recently used entries are evicted.
+type diskCache struct {
+	dir      string
+	maxBytes int64
+
+	// size is the total size of the entries, read from the directory on the
+	// first put and then kept up to date by put and evict, so that only a put
+	// that crosses maxBytes walks the directory
+	mu      sync.Mutex
+	size    int64
+	counted bool
+}
+
+// evictTarget is the fraction of maxBytes eviction shrinks the cache to, so
+// the puts that follow do not each trigger another eviction
+const evictTarget = 0.9
+
+// newDiskCache returns the cache selected by --cache-dir, or nil when caching
+// is disabled
+func newDiskCache() (*diskCache, error) {
+	if cacheDir == "" || noCache {
+		return nil, nil
+	}
+	if cacheSizeMB <= 0 {
+		return nil, fmt.Errorf("invalid --cache-size %d: must be at least 1 MB", cacheSizeMB)
+	}
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &diskCache{dir: cacheDir, maxBytes: cacheSizeMB << 20}, nil
+}
+
+// path maps a cache key to its file
+func (d *diskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:]))
+}
+
+// get returns the cached value for key, marking it as recently used
+func (d *diskCache) get(key string) ([]byte, bool) {
+	path := d.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return data, true
+}
+
+// put stores a value and evicts old entries if the cache is over its size
+// limit. Failures are ignored: the cache only ever saves requests.
+func (d *diskCache) put(key string, data []byte) {
+	tmp, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.counted {
+		d.size, _ = d.entries()
+		d.counted = true
+	}
+	path := d.path(key)
+	var replaced int64
+	if info, err := os.Stat(path); err == nil {
+		replaced = info.Size()
+	}
+	if os.Rename(tmp.Name(), path) != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	d.size += int64(len(data)) - replaced
+	if d.size > d.maxBytes {
+		d.evict()
+	}
+}
+
+// cacheEntry is a file in the cache directory
+type cacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// entries lists the cached files and returns their total size
+func (d *diskCache) entries() (int64, []cacheEntry) {
+	var (
+		entries []cacheEntry
+		total   int64
+	)
+	filepath.WalkDir(d.dir, func(path string, de fs.DirEntry, err error) error {
+		if err != nil || de.IsDir() || strings.HasPrefix(de.Name(), ".") {
+			return nil
+		}
+		info, err := de.Info()
+		if err != nil {
+			return nil
+		}
+		entries = append(entries, cacheEntry{path, info.Size(), info.ModTime()})
+		total += info.Size()
+		return nil
+	})
+	return total, entries
+}
+
+// evict removes the least recently used entries until the cache is back to
+// evictTarget of maxBytes. d.mu must be held.
+func (d *diskCache) evict() {
+	total, entries := d.entries()
+	target := int64(float64(d.maxBytes) * evictTarget)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].modTime.Before(entries[j].modTime) })
+	for _, e := range entries {
+		if total <= target {
+			break
+		}
+		if os.Remove(e.path) == nil {
+			total -= e.size
+		}
+	}
+	d.size = total
+}
+
+// cacheKey builds a chainID:method:args key. The chain ID is read once per run,
+// outside cacheMu so concurrent lookups do not queue behind the request.
+func (c *Client) cacheKey(method, args string) (string, error) {
+	c.cacheMu.Lock()
+	chainID := c.cacheChainID
+	c.cacheMu.Unlock()
+	if chainID == nil {
+		var err error
+		if chainID, err = c.GetChainID(); err != nil {
+			return "", err
+		}
+		c.cacheMu.Lock()
+		c.cacheChainID = chainID
+		c.cacheMu.Unlock()
+	}
+	return fmt.Sprintf("%s:%s:%s", chainID, method, args), nil
+}
+
+// isFinal reports whether a block can no longer be reorged and so its data
+// may be cached. Chains without a finalized tag are never cached. The
+// finalized block is fetched outside cacheMu, and only when the remembered one
+// is below number.
+func (c *Client) isFinal(number *big.Int) bool {
+	c.cacheMu.Lock()
+	finalized := c.cacheFinalized
+	c.cacheMu.Unlock()
+	if finalized != nil && finalized.Cmp(number) >= 0 {
+		return true
+	}
+
+	finalized, supported, err := c.GetFinalizedBlockNumber()
+	if err != nil || !supported {
+		return false
+	}
+	c.cacheMu.Lock()
+	if c.cacheFinalized == nil || c.cacheFinalized.Cmp(finalized) < 0 {
+		c.cacheFinalized = finalized
+	}
+	c.cacheMu.Unlock()
+	return finalized.Cmp(number) >= 0
+}
+
+// cachedBlock returns a block from the cache or fetches it with fetch,
+// storing it when it is finalized. Blocks are stored RLP-encoded.
+func (c *Client) cachedBlock(method, args string, fetch func() (*types.Block, error)) (*types.Block, error) {
+	key, err := c.cacheKey(method, args)
+	if err != nil {
+		return nil, err
+	}
+	if data, ok := c.cache.get(key); ok {
+		block := new(types.Block)
+		if rlp.DecodeBytes(data, block) == nil {
+			return block, nil
+		}
+	}
+
+	block, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if c.isFinal(block.Number()) {
+		if data, err := rlp.EncodeToBytes(block); err == nil {
+			c.cache.put(key, data)
+		}
+	}
+	return block, nil
+}
+
+// cachedReceipt returns a receipt from the cache or fetches it with fetch,
+// storing it when its block is finalized
+func (c *Client) cachedReceipt(hash string, fetch func() (*types.Receipt, error)) (*types.Receipt, error) {
+	key, err := c.cacheKey("eth_getTransactionReceipt", strings.ToLower(hash))
+	if err != nil {
+		return nil, err
+	}
+	if data, ok := c.cache.get(key); ok {
+		receipt := new(types.Receipt)
+		if json.Unmarshal(data, receipt) == nil {
+			return receipt, nil
+		}
+	}
+
+	receipt, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if c.isFinal(receipt.BlockNumber) {
+		if data, err := json.Marshal(receipt); err == nil {
+			c.cache.put(key, data)
+		}
+	}
+	return receipt, nil
+}
+
+// cachedTransaction returns a mined transaction from the cache, or fetches
+// the raw eth_getTransactionByHash response and stores it when the
+// transaction's block is finalized. The raw response is used because it
+// carries the block number that ethclient does not expose.
+func (c *Client) cachedTransaction(hash common.Hash) (*types.Transaction, bool, error) {
+	key, err := c.cacheKey("eth_getTransactionByHash", strings.ToLower(hash.Hex()))
+	if err != nil {
+		return nil, false, err
+	}
+	if data, ok := c.cache.get(key); ok {
+		tx := new(types.Transaction)
+		if tx.UnmarshalJSON(data) == nil {
+			return tx, false, nil
+		}
+	}
+
+	var raw json.RawMessage
+	err = c.withRetry(func(ec *ethclient.Client) error {
+		return ec.Client().CallContext(c.ctx, &raw, "eth_getTransactionByHash", hash)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, false, ethereum.NotFound
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalJSON(raw); err != nil {
+		return nil, false, err
+	}
+	var extra struct {
+		BlockNumber *hexutil.Big `json:"blockNumber"`
+	}
+	if err := json.Unmarshal(raw, &extra); err != nil {
+		return nil, false, err
+	}
+	if extra.BlockNumber == nil {
+		return tx, true, nil
+	}
+	if c.isFinal(extra.BlockNumber.ToInt()) {
+		c.cache.put(key, raw)
+	}
+	return tx, false, nil
+}
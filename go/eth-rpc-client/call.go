@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	callAddress  string
+	callMethod   string
+	callArgs     []string
+	callBlock    string
+	callOverride string
+)
+
+// CallFunction packs a contract call, executes it at the given block (the
+// latest block when block is nil) against state modified by overrides, if
+// any, and unpacks the returned values. A revert is reported with its decoded
+// reason when the node returns one.
+func (c *Client) CallFunction(contract common.Address, contractABI abi.ABI, method string, args []any, block *big.Int, overrides stateOverride) ([]any, error) {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+
+	var result []byte
+	if len(overrides) == 0 {
+		err = c.withRetry(func(ec *ethclient.Client) (err error) {
+			result, err = ec.CallContract(c.ctx, ethereum.CallMsg{To: &contract, Data: data}, block)
+			return err
+		})
+	} else {
+		// ethclient.CallContract has no way to pass overrides, so the call is
+		// made through the raw RPC client
+		blockArg := "latest"
+		if block != nil {
+			blockArg = rpc.BlockNumber(block.Int64()).String()
+		}
+		arg := map[string]any{"to": contract, "input": hexutil.Bytes(data)}
+		err = c.withRetry(func(ec *ethclient.Client) error {
+			var raw hexutil.Bytes
+			err := ec.Client().CallContext(c.ctx, &raw, "eth_call", arg, blockArg, overrides)
+			result = raw
+			return err
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", method, wrapRevert(err))
+	}
+
+	values, err := contractABI.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return values, nil
+}
+
+// CallResult is the result of the call command
+type CallResult struct {
+	Address string     `json:"address"`
+	Method  string     `json:"method"`
+	Outputs []ABIValue `json:"outputs"`
+}
+
+func (r CallResult) renderText(w io.Writer) {
+	if len(r.Outputs) == 0 {
+		fmt.Fprintln(w, "(no return values)")
+		return
+	}
+	for i, out := range r.Outputs {
+		label := out.Name
+		if label == "" {
+			label = fmt.Sprintf("[%d]", i)
+		}
+		printField(w, fmt.Sprintf("%s (%s)", label, out.Type), out.Value)
+	}
+}
+
+var callCmd = &cobra.Command{
+	Use:   "call",
+	Short: "Call a read-only contract method using its ABI",
+	Long: `Encodes a method call from the contract's ABI (--abi, or --abi-etherscan to
+fetch the verified one), executes it with eth_call and decodes the return values. Arguments are given in order with repeated --arg flags:
+addresses as hex, integers in decimal or 0x hex, bools as true/false, bytes as
+0x hex and arrays as JSON arrays, e.g. --arg '["0x01","0x02"]'.
+
+--override simulates the call against modified state without deploying
+anything: a JSON object (or file) mapping addresses to the balance, nonce,
+code, state or stateDiff to use for this call only, with quantities in 0x hex.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		contract, err := rpcClient.Resolve(callAddress)
+		if err != nil {
+			fatal(err)
+		}
+		contractABI, err := rpcClient.ContractABI(contract)
+		if err != nil {
+			fatal(err)
+		}
+
+		method, ok := contractABI.Methods[callMethod]
+		if !ok {
+			fatalf("method %q not found in ABI", callMethod)
+		}
+		if len(callArgs) != len(method.Inputs) {
+			fatalf("%s expects %d arguments, got %d", method.Sig, len(method.Inputs), len(callArgs))
+		}
+
+		values := make([]any, len(callArgs))
+		for i, input := range method.Inputs {
+			if values[i], err = parseArg(input.Type, callArgs[i]); err != nil {
+				fatalf("argument %d (%s): %v", i, input.Type, err)
+			}
+		}
+
+		var block *big.Int
+		if callBlock != "" {
+			if block, err = parseBlockTag(callBlock); err != nil {
+				fatal(err)
+			}
+		}
+
+		var overrides stateOverride
+		if callOverride != "" {
+			if overrides, err = loadStateOverride(callOverride); err != nil {
+				fatal(err)
+			}
+		}
+
+		results, err := rpcClient.CallFunction(contract, contractABI, method.Name, values, block, overrides)
+		if err != nil {
+			fatal(err)
+		}
+
+		result := CallResult{Address: contract.Hex(), Method: method.Sig, Outputs: []ABIValue{}}
+		for i, output := range method.Outputs {
+			result.Outputs = append(result.Outputs, ABIValue{
+				Name:  output.Name,
+				Type:  output.Type.String(),
+				Value: formatValue(results[i]),
+			})
+		}
+
+		render(result)
+	},
+}
+
+func init() {
+	callCmd.Flags().StringVar(&callAddress, "address", "", "Contract address or ENS name")
+	addABIFlags(callCmd, "Contract's JSON ABI as a file, inline JSON or a build artifact containing one")
+	callCmd.Flags().StringVar(&callMethod, "method", "", "Method name to call")
+	callCmd.Flags().StringArrayVar(&callArgs, "arg", nil, "Method argument, repeated in order")
+	callCmd.Flags().StringVar(&callBlock, "block", "", "Block number or tag to call at (default latest)")
+	callCmd.Flags().StringVar(&callOverride, "override", "", "State override as JSON or a JSON file: {address: {balance, nonce, code, state, stateDiff}}")
+	callCmd.MarkFlagRequired("address")
+	callCmd.MarkFlagsOneRequired("abi", "abi-etherscan")
+	callCmd.MarkFlagRequired("method")
+
+	rootCmd.AddCommand(callCmd)
+}
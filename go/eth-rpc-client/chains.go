@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// builtinChainNames maps well-known chain IDs to their network names.
+// Entries under chains in the config file take precedence.
+var builtinChainNames = map[uint64]string{
+	1:        "Ethereum Mainnet",
+	10:       "OP Mainnet",
+	56:       "BNB Smart Chain",
+	100:      "Gnosis",
+	137:      "Polygon",
+	250:      "Fantom Opera",
+	324:      "zkSync Era",
+	1101:     "Polygon zkEVM",
+	8453:     "Base",
+	17000:    "Holesky",
+	31337:    "Hardhat/Anvil",
+	42161:    "Arbitrum One",
+	42220:    "Celo",
+	43114:    "Avalanche C-Chain",
+	59144:    "Linea",
+	80002:    "Polygon Amoy",
+	84532:    "Base Sepolia",
+	421614:   "Arbitrum Sepolia",
+	534352:   "Scroll",
+	11155111: "Sepolia",
+	11155420: "OP Sepolia",
+}
+
+// configChainNames holds the chains section of the config file
+var configChainNames map[uint64]string
+
+// chainName returns a chain ID's network name, or "" when it is not known
+func chainName(chainID *big.Int) string {
+	if !chainID.IsUint64() {
+		return ""
+	}
+	if name, ok := configChainNames[chainID.Uint64()]; ok {
+		return name
+	}
+	return builtinChainNames[chainID.Uint64()]
+}
+
+// describeChain formats a chain ID with its name, e.g. "Ethereum Mainnet (1)",
+// or "Unknown (N)" for unrecognized chains
+func describeChain(chainID *big.Int) string {
+	name := chainName(chainID)
+	if name == "" {
+		name = "Unknown"
+	}
+	return fmt.Sprintf("%s (%s)", name, chainID)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// semverPattern matches the version component of a client version string,
+// e.g. "v1.13.14-stable-2bd6bd01" or "2.55.1"
+var semverPattern = regexp.MustCompile(`^v?(\d+\.\d+\.\d+)`)
+
+// knownClients maps lower-cased client names to their display names
+var knownClients = map[string]string{
+	"geth":       "Geth",
+	"erigon":     "Erigon",
+	"nethermind": "Nethermind",
+	"besu":       "Besu",
+	"reth":       "Reth",
+	"anvil":      "Anvil",
+	"hardhat":    "Hardhat",
+	"ganache":    "Ganache",
+}
+
+// parseClientVersion extracts the client family and semantic version from a
+// web3_clientVersion string such as "Geth/v1.13.14-stable/linux-amd64/go1.21.7".
+// Either result is empty when it cannot be detected.
+func parseClientVersion(version string) (family, semver string) {
+	parts := strings.Split(version, "/")
+	name := strings.ToLower(strings.TrimSpace(parts[0]))
+	for prefix, display := range knownClients {
+		if strings.HasPrefix(name, prefix) {
+			family = display
+			break
+		}
+	}
+
+	for _, part := range parts[1:] {
+		if m := semverPattern.FindStringSubmatch(part); m != nil {
+			semver = m[1]
+			break
+		}
+	}
+	return family, semver
+}
+
+// ClientVersionInfo is the result of the client-version command
+type ClientVersionInfo struct {
+	Version string `json:"version"`
+	Client  string `json:"client,omitempty"`
+	Semver  string `json:"semver,omitempty"`
+}
+
+func (v ClientVersionInfo) renderText(w io.Writer) {
+	printField(w, "Version", v.Version)
+	if v.Client != "" {
+		printField(w, "Client", v.Client)
+	}
+	if v.Semver != "" {
+		printField(w, "Release", v.Semver)
+	}
+}
+
+var clientVersionCmd = &cobra.Command{
+	Use:   "client-version",
+	Short: "Show the node's client software and version (web3_clientVersion)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		version, err := rpcClient.GetClientVersion()
+		if err != nil {
+			fatal(err)
+		}
+
+		info := ClientVersionInfo{Version: version}
+		info.Client, info.Semver = parseClientVersion(version)
+
+		render(info)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(clientVersionCmd)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var (
+	codeRaw   bool
+	codeBlock string
+)
+
+// GetCode returns the deployed bytecode of an address at the given block, or
+// the latest block when block is nil. EOAs and empty accounts return no code.
+func (c *Client) GetCode(addr string, block *big.Int) ([]byte, error) {
+	account, err := c.Resolve(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var code []byte
+	err = c.withRetry(func(ec *ethclient.Client) (err error) {
+		code, err = ec.CodeAt(c.ctx, account, block)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get code: %w", err)
+	}
+	return code, nil
+}
+
+// CodeInfo is the result of the code command
+type CodeInfo struct {
+	Address string `json:"address"`
+	Size    int    `json:"size"`
+	Code    string `json:"code,omitempty"`
+}
+
+func (c CodeInfo) renderText(w io.Writer) {
+	if c.Size == 0 {
+		printField(w, "Code", "not a contract (EOA or empty)")
+		return
+	}
+	printField(w, "Code Size", fmt.Sprintf("%d bytes", c.Size))
+	if c.Code != "" {
+		fmt.Fprintln(w, c.Code)
+	}
+}
+
+var codeCmd = &cobra.Command{
+	Use:   "code [address]",
+	Short: "Get the deployed bytecode of an address",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var block *big.Int
+		if codeBlock != "" {
+			var err error
+			if block, err = parseBlockTag(codeBlock); err != nil {
+				fatal(err)
+			}
+		}
+
+		addr, err := rpcClient.Resolve(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		code, err := rpcClient.GetCode(addr.Hex(), block)
+		if err != nil {
+			fatal(err)
+		}
+
+		info := CodeInfo{Address: addr.Hex(), Size: len(code)}
+		if codeRaw && len(code) > 0 {
+			info.Code = hexutil.Encode(code)
+		}
+
+		render(info)
+	},
+}
+
+func init() {
+	codeCmd.Flags().BoolVar(&codeRaw, "raw", false, "Print the full bytecode in hex")
+	codeCmd.Flags().StringVar(&codeBlock, "block", "", "Block number or tag to read at (default latest)")
+
+	rootCmd.AddCommand(codeCmd)
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile is the config file looked up in the home directory
+const defaultConfigFile = ".eth-rpc.yaml"
+
+var (
+	configPath  string
+	networkName string
+
+	// addressBook maps aliases from the config file to addresses
+	addressBook map[string]common.Address
+
+	// expectedChainID is the chain ID of the selected --network, checked when
+	// connecting so a profile pointing at the wrong chain is caught early
+	expectedChainID uint64
+)
+
+// Config is the contents of the config file
+type Config struct {
+	Networks  map[string]NetworkConfig `yaml:"networks"`
+	Addresses map[string]string        `yaml:"addresses"`
+
+	// Explorers maps chain IDs to block explorer base URLs, overriding the
+	// built-in list used by --explorer
+	Explorers map[uint64]string `yaml:"explorers"`
+
+	// Chains maps chain IDs to network names, extending or overriding the
+	// built-in names shown by info
+	Chains map[uint64]string `yaml:"chains"`
+}
+
+// NetworkConfig is a named RPC profile
+type NetworkConfig struct {
+	URL     string `yaml:"url"`
+	ChainID uint64 `yaml:"chain_id"`
+}
+
+// loadConfig reads the config file at path. A missing file is only an error
+// when the path was given explicitly.
+func loadConfig(path string, explicit bool) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	for name, network := range config.Networks {
+		if network.URL == "" {
+			return nil, fmt.Errorf("network %q in %s has no url", name, path)
+		}
+	}
+	for chainID, url := range config.Explorers {
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			return nil, fmt.Errorf("explorer for chain %d in %s is not an http(s) URL: %q", chainID, path, url)
+		}
+	}
+	for chainID, name := range config.Chains {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("chain %d in %s has an empty name", chainID, path)
+		}
+	}
+	for alias, addr := range config.Addresses {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("address alias %q in %s is not a hex address: %q", alias, path, addr)
+		}
+	}
+	return &config, nil
+}
+
+// applyConfig loads the config file and applies the selected --network and
+// the address book. An explicit --rpc takes precedence over the network's URL.
+func applyConfig(cmd *cobra.Command) error {
+	path, explicit := configPath, configPath != ""
+	if !explicit {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(home, defaultConfigFile)
+	}
+
+	config, err := loadConfig(path, explicit)
+	if err != nil {
+		return err
+	}
+
+	addressBook = make(map[string]common.Address, len(config.Addresses))
+	for alias, addr := range config.Addresses {
+		addressBook[alias] = common.HexToAddress(addr)
+	}
+	configExplorers = config.Explorers
+	configChainNames = config.Chains
+
+	if networkName == "" {
+		return nil
+	}
+	network, ok := config.Networks[networkName]
+	if !ok {
+		return fmt.Errorf("unknown network %q (not defined in %s)", networkName, path)
+	}
+	if !cmd.Flags().Changed("rpc") {
+		rpcURLs = []string{network.URL}
+	}
+	expectedChainID = network.ChainID
+	return nil
+}
+
+// lookupAlias returns the address-book entry for an alias
+func lookupAlias(alias string) (common.Address, bool) {
+	addr, ok := addressBook[alias]
+	return addr, ok
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contractDeployer     string
+	contractNonce        uint64
+	contractSalt         string
+	contractInitCodeHash string
+)
+
+// parseBytes32 parses a 0x-prefixed hex value of exactly 32 bytes
+func parseBytes32(name, s string) ([32]byte, error) {
+	var out [32]byte
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) != 32 {
+		return out, fmt.Errorf("invalid %s %q (expected 0x followed by 64 hex characters)", name, s)
+	}
+	copy(out[:], b)
+	return out, nil
+}
+
+// ContractAddressInfo is the result of the contract-address command
+type ContractAddressInfo struct {
+	Method  string `json:"method"`
+	Address string `json:"address"`
+}
+
+func (c ContractAddressInfo) renderText(w io.Writer) {
+	printField(w, c.Method+" Address", c.Address)
+}
+
+var contractAddressCmd = &cobra.Command{
+	Use:   "contract-address",
+	Short: "Compute a CREATE or CREATE2 deployment address offline",
+	Long: `Predicts the address of a contract deployment without an RPC connection.
+CREATE:  --deployer and --nonce
+CREATE2: --deployer, --salt and --init-code-hash (keccak256 of the init code)`,
+	Annotations: map[string]string{annotationOffline: ""},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !common.IsHexAddress(contractDeployer) {
+			fatalf("invalid deployer address %q", contractDeployer)
+		}
+		deployer := common.HexToAddress(contractDeployer)
+
+		create2 := contractSalt != "" || contractInitCodeHash != ""
+		if create2 && cmd.Flags().Changed("nonce") {
+			fatal("--nonce (CREATE) cannot be combined with --salt/--init-code-hash (CREATE2)")
+		}
+
+		if !create2 {
+			if !cmd.Flags().Changed("nonce") {
+				fatal("--nonce is required for CREATE, or --salt and --init-code-hash for CREATE2")
+			}
+			render(ContractAddressInfo{
+				Method:  "CREATE",
+				Address: crypto.CreateAddress(deployer, contractNonce).Hex(),
+			})
+			return
+		}
+
+		salt, err := parseBytes32("salt", contractSalt)
+		if err != nil {
+			fatal(err)
+		}
+		initCodeHash, err := parseBytes32("init code hash", contractInitCodeHash)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(ContractAddressInfo{
+			Method:  "CREATE2",
+			Address: crypto.CreateAddress2(deployer, salt, initCodeHash[:]).Hex(),
+		})
+	},
+}
+
+func init() {
+	contractAddressCmd.Flags().StringVar(&contractDeployer, "deployer", "", "Deploying account or factory address")
+	contractAddressCmd.Flags().Uint64Var(&contractNonce, "nonce", 0, "Deployer nonce (CREATE)")
+	contractAddressCmd.Flags().StringVar(&contractSalt, "salt", "", "32-byte hex salt (CREATE2)")
+	contractAddressCmd.Flags().StringVar(&contractInitCodeHash, "init-code-hash", "", "32-byte hex keccak256 of the init code (CREATE2)")
+	contractAddressCmd.MarkFlagRequired("deployer")
+
+	rootCmd.AddCommand(contractAddressCmd)
+}
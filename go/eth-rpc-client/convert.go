@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ConversionResult is the result of the convert command. Wei is the exact
+// amount in wei, Value the amount in the target unit.
+type ConversionResult struct {
+	Amount string `json:"amount"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Value  string `json:"value"`
+	Wei    string `json:"wei"`
+}
+
+func (c ConversionResult) renderText(w io.Writer) {
+	fmt.Fprintf(w, "%s %s = %s %s\n", c.Amount, c.From, green(c.Value), c.To)
+}
+
+var convertCmd = &cobra.Command{
+	Use:   "convert [amount] [from-unit] [to-unit]",
+	Short: "Convert an amount between ether denominations",
+	Long: `Converts an amount between wei, kwei, mwei, gwei, szabo, finney and ether
+(or eth) exactly, using integer arithmetic, e.g.
+
+  eth-rpc convert 1.5 ether gwei     # 1.5 ether = 1500000000 gwei
+  eth-rpc convert 21000 gwei         # to wei when no target unit is given
+
+An amount with more decimal places than the source unit allows, such as
+0.5 wei, is rejected. No RPC connection is made.`,
+	Annotations: map[string]string{annotationOffline: ""},
+	Args:        cobra.RangeArgs(2, 3),
+	Run: func(cmd *cobra.Command, args []string) {
+		from, to := strings.ToLower(args[1]), UnitWei
+		if len(args) == 3 {
+			to = strings.ToLower(args[2])
+		}
+		fromDecimals, err := lookupUnit(from)
+		if err != nil {
+			fatal(err)
+		}
+		toDecimals, err := lookupUnit(to)
+		if err != nil {
+			fatal(err)
+		}
+
+		wei, err := parseUnits(args[0], fromDecimals)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(ConversionResult{
+			Amount: args[0],
+			From:   from,
+			To:     to,
+			Value:  formatUnits(wei, toDecimals),
+			Wei:    wei.String(),
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+var decodeData string
+
+// loadCalldata reads calldata given as hex, with or without 0x, or a file
+// containing hex
+func loadCalldata(value string) ([]byte, error) {
+	if data, err := os.ReadFile(value); err == nil {
+		value = strings.TrimSpace(string(data))
+	}
+	if !strings.HasPrefix(value, "0x") {
+		value = "0x" + value
+	}
+	data, err := hexutil.Decode(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --data: %w", err)
+	}
+	return data, nil
+}
+
+// CalldataInfo is the result of the decode-calldata command
+type CalldataInfo struct {
+	Selector string     `json:"selector"`
+	Method   string     `json:"method"`
+	Args     []ABIValue `json:"args"`
+}
+
+func (c CalldataInfo) renderText(w io.Writer) {
+	printField(w, "Method", c.Method)
+	printField(w, "Selector", c.Selector)
+	printABIValues(w, c.Args, "  ")
+}
+
+// printABIValues prints decoded values one per line, expanding tuples and
+// arrays into their components at a deeper indent. Array elements are shown
+// by index alone since the array's type already gives theirs.
+func printABIValues(w io.Writer, values []ABIValue, indent string) {
+	for i, v := range values {
+		label := fmt.Sprintf("%s%s (%s)", indent, v.Name, v.Type)
+		switch {
+		case v.Name == "":
+			label = fmt.Sprintf("%s[%d] (%s)", indent, i, v.Type)
+		case strings.HasPrefix(v.Name, "["):
+			label = indent + v.Name
+		}
+		if len(v.Components) == 0 {
+			printField(w, label, v.Value)
+			continue
+		}
+		fmt.Fprintln(w, cyan(label+":"))
+		printABIValues(w, v.Components, indent+"  ")
+	}
+}
+
+var decodeCalldataCmd = &cobra.Command{
+	Use:   "decode-calldata",
+	Short: "Decode calldata against an ABI without a transaction",
+	Long: `Identifies the method called by --data from its 4-byte selector and decodes
+the arguments with their names from --abi. Tuples and arrays, however deeply
+nested, are expanded field by field. Useful for checking a multisig proposal or
+a simulated call before it is executed; no RPC connection is made.
+
+--data takes hex, with or without 0x, or a file containing hex. When the
+selector is not in the ABI, selector --lookup can identify the method.`,
+	Annotations: map[string]string{annotationOffline: ""},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		contractABI, err := loadABI(abiSource)
+		if err != nil {
+			fatal(err)
+		}
+		data, err := loadCalldata(decodeData)
+		if err != nil {
+			fatal(err)
+		}
+
+		if len(data) < 4 {
+			fatal("calldata too short for a method selector")
+		}
+		selector := hexutil.Encode(data[:4])
+		if _, err := contractABI.MethodById(data[:4]); err != nil {
+			fatalf("unknown method selector %s; eth-rpc selector --lookup %s may identify it", selector, selector)
+		}
+
+		call, err := decodeCalldata(contractABI, data)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(CalldataInfo{
+			Selector: selector,
+			Method:   call.Method,
+			Args:     call.Args,
+		})
+	},
+}
+
+func init() {
+	decodeCalldataCmd.Flags().StringVar(&abiSource, "abi", "", "JSON ABI or Hardhat/Foundry artifact, as a file or inline JSON")
+	decodeCalldataCmd.Flags().StringVar(&decodeData, "data", "", "Calldata as hex or a file containing hex")
+	decodeCalldataCmd.MarkFlagRequired("abi")
+	decodeCalldataCmd.MarkFlagRequired("data")
+
+	rootCmd.AddCommand(decodeCalldataCmd)
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deployBytecode string
+	deployArgs     []string
+	deployValue    string
+	deployGasLimit uint64
+)
+
+// DeployContract sends a contract creation transaction with the given init
+// code (bytecode followed by the encoded constructor arguments) and returns
+// it. A zero gas limit is estimated from the creation data.
+func (c *Client) DeployContract(priv *ecdsa.PrivateKey, initCode []byte, value *big.Int, gas uint64) (*types.Transaction, error) {
+	return c.sendDynamicFeeTx(priv, nil, value, initCode, gas, nil)
+}
+
+// loadBytecode reads contract bytecode given as hex, a file containing hex,
+// or a Hardhat or Foundry artifact JSON file
+func loadBytecode(value string) ([]byte, error) {
+	if data, err := os.ReadFile(value); err == nil {
+		value = strings.TrimSpace(string(data))
+		if strings.HasPrefix(value, "{") {
+			var artifact struct {
+				Bytecode json.RawMessage `json:"bytecode"`
+			}
+			if err := json.Unmarshal(data, &artifact); err != nil {
+				return nil, fmt.Errorf("failed to parse artifact: %w", err)
+			}
+			// Hardhat stores the bytecode as a string, Foundry as {"object": ...}
+			var foundry struct {
+				Object string `json:"object"`
+			}
+			if json.Unmarshal(artifact.Bytecode, &value) != nil {
+				if json.Unmarshal(artifact.Bytecode, &foundry) != nil || foundry.Object == "" {
+					return nil, fmt.Errorf("artifact has no bytecode")
+				}
+				value = foundry.Object
+			}
+		}
+	}
+
+	if !strings.HasPrefix(value, "0x") {
+		value = "0x" + value
+	}
+	bytecode, err := hexutil.Decode(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --bytecode: %w", err)
+	}
+	if len(bytecode) == 0 {
+		return nil, fmt.Errorf("--bytecode is empty")
+	}
+	return bytecode, nil
+}
+
+// packConstructor ABI-encodes constructor arguments given as strings (see parseArg)
+func packConstructor(contractABI abi.ABI, args []string) ([]byte, error) {
+	inputs := contractABI.Constructor.Inputs
+	if len(args) != len(inputs) {
+		return nil, fmt.Errorf("constructor expects %d arguments, got %d", len(inputs), len(args))
+	}
+
+	values := make([]any, len(args))
+	for i, input := range inputs {
+		var err error
+		if values[i], err = parseArg(input.Type, args[i]); err != nil {
+			return nil, fmt.Errorf("argument %d (%s): %w", i, input.Type, err)
+		}
+	}
+	return contractABI.Pack("", values...)
+}
+
+// DeployResult is the result of the deploy command
+type DeployResult struct {
+	Hash            string `json:"hash"`
+	From            string `json:"from"`
+	ContractAddress string `json:"contractAddress"`
+	Status          string `json:"status"`
+	BlockNumber     uint64 `json:"blockNumber"`
+	GasUsed         uint64 `json:"gasUsed"`
+}
+
+func (r DeployResult) renderText(w io.Writer) {
+	printField(w, "Transaction", r.Hash)
+	fmt.Fprintf(w, "%s %s\n", cyan("Status:"), statusString(r.Status))
+	printField(w, "Contract Address", r.ContractAddress)
+	printField(w, "Block", r.BlockNumber)
+	printField(w, "Gas Used", r.GasUsed)
+}
+
+var deployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Deploy a contract and wait for its address",
+	Long: `Sends a contract creation transaction and waits for it to be mined, then
+prints the deployed contract's address. --bytecode takes hex, a file containing
+hex, or a Hardhat or Foundry artifact. Constructor arguments are given in order
+with repeated --arg flags (formatted as for call) and need --abi, which may be
+the same artifact. Gas is estimated from the creation data unless --gas-limit
+is set. Exits non-zero if the deployment reverted.`,
+	Annotations: map[string]string{annotationLongRunning: ""},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		initCode, err := loadBytecode(deployBytecode)
+		if err != nil {
+			fatal(err)
+		}
+
+		if abiSource != "" {
+			contractABI, err := loadABI(abiSource)
+			if err != nil {
+				fatal(err)
+			}
+			packed, err := packConstructor(contractABI, deployArgs)
+			if err != nil {
+				fatal(err)
+			}
+			initCode = append(initCode, packed...)
+		} else if len(deployArgs) > 0 {
+			fatal("constructor arguments need --abi")
+		}
+
+		value := new(big.Int)
+		if deployValue != "" {
+			if value, err = parseUnits(deployValue, 18); err != nil {
+				fatal(err)
+			}
+		}
+
+		priv, err := loadSigningKey(cmd)
+		if err != nil {
+			fatal(err)
+		}
+		defer zeroKey(priv)
+
+		from := crypto.PubkeyToAddress(priv.PublicKey)
+		tx, err := rpcClient.DeployContract(priv, initCode, value, deployGasLimit)
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Fprintf(progressWriter(), "Waiting for %s to be mined...\n", tx.Hash().Hex())
+
+		receipt, err := rpcClient.WaitMined(tx.Hash(), 0)
+		if err != nil {
+			fatal(err)
+		}
+
+		address := receipt.ContractAddress
+		if address == (common.Address{}) {
+			address = crypto.CreateAddress(from, tx.Nonce())
+		}
+		render(DeployResult{
+			Hash:            tx.Hash().Hex(),
+			From:            from.Hex(),
+			ContractAddress: address.Hex(),
+			Status:          receiptStatus(receipt.Status),
+			BlockNumber:     receipt.BlockNumber.Uint64(),
+			GasUsed:         receipt.GasUsed,
+		})
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	deployCmd.Flags().StringVar(&deployBytecode, "bytecode", "", "Contract bytecode as hex, a file containing hex, or a Hardhat/Foundry artifact")
+	deployCmd.Flags().StringVar(&abiSource, "abi", "", "JSON ABI or artifact with the constructor, as a file or inline JSON")
+	deployCmd.Flags().StringArrayVar(&deployArgs, "arg", nil, "Constructor argument (repeatable, in order)")
+	deployCmd.Flags().StringVar(&deployValue, "value", "", "ETH to send to the constructor, e.g. 0.1")
+	deployCmd.Flags().Uint64Var(&deployGasLimit, "gas-limit", 0, "Gas limit (estimated when omitted)")
+	addKeyFlags(deployCmd)
+	deployCmd.MarkFlagRequired("bytecode")
+
+	rootCmd.AddCommand(deployCmd)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// isIPCEndpoint reports whether an --rpc value names a local IPC socket: a
+// unix:// URL or a filesystem path such as /tmp/geth.ipc or ~/.ethereum/geth.ipc
+func isIPCEndpoint(url string) bool {
+	if strings.HasPrefix(url, "unix://") {
+		return true
+	}
+	if strings.Contains(url, "://") {
+		return false
+	}
+	return filepath.IsAbs(url) ||
+		strings.HasPrefix(url, "~/") ||
+		strings.HasPrefix(url, "./") ||
+		strings.HasPrefix(url, `\\.\pipe\`) ||
+		strings.HasSuffix(url, ".ipc")
+}
+
+// dialTarget returns the address to dial for an --rpc value. IPC endpoints
+// are reduced to a plain socket path, which go-ethereum dials over IPC.
+func dialTarget(url string) string {
+	if !isIPCEndpoint(url) {
+		return url
+	}
+	path := strings.TrimPrefix(url, "unix://")
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	return path
+}
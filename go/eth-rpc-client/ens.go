@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ensRegistry is the address of the ENS registry, identical on mainnet and testnets
+var ensRegistry = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+const ensABIJSON = `[
+	{"constant":true,"inputs":[{"name":"node","type":"bytes32"}],"name":"resolver","outputs":[{"name":"","type":"address"}],"type":"function"},
+	{"constant":true,"inputs":[{"name":"node","type":"bytes32"}],"name":"addr","outputs":[{"name":"","type":"address"}],"type":"function"}
+]`
+
+var ensABI = mustParseABI(ensABIJSON)
+
+// isENSName reports whether s looks like an ENS name rather than a hex address
+func isENSName(s string) bool {
+	return strings.HasSuffix(strings.ToLower(s), ".eth")
+}
+
+// ensNamehash computes the EIP-137 namehash of an ENS name. Names are
+// lower-cased; full UTS-46 normalization is not applied.
+func ensNamehash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := crypto.Keccak256([]byte(labels[i]))
+		node = crypto.Keccak256Hash(node.Bytes(), labelHash)
+	}
+	return node
+}
+
+// Resolve returns the address for a hex address, an address-book alias or an
+// ENS name. Hex addresses are returned unchanged, aliases are looked up in the
+// config file and .eth names are resolved through the ENS registry.
+func (c *Client) Resolve(nameOrAddr string) (common.Address, error) {
+	if common.IsHexAddress(nameOrAddr) {
+		return common.HexToAddress(nameOrAddr), nil
+	}
+	if addr, ok := lookupAlias(nameOrAddr); ok {
+		return addr, nil
+	}
+	if !isENSName(nameOrAddr) {
+		return common.Address{}, fmt.Errorf("%q is neither a hex address, an address alias nor an ENS name", nameOrAddr)
+	}
+
+	node := ensNamehash(nameOrAddr)
+
+	values, err := c.callMethod(ensRegistry, ensABI, "resolver", node)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to resolve ENS name %s: %w", nameOrAddr, err)
+	}
+	resolver := values[0].(common.Address)
+	if resolver == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("ENS name %s has no resolver", nameOrAddr)
+	}
+
+	values, err = c.callMethod(resolver, ensABI, "addr", node)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to resolve ENS name %s: %w", nameOrAddr, err)
+	}
+	addr := values[0].(common.Address)
+	if addr == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("ENS name %s does not resolve to an address", nameOrAddr)
+	}
+	return addr, nil
+}
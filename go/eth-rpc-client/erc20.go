@@ -0,0 +1,402 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/spf13/cobra"
+)
+
+const erc20ABIJSON = `[
+	{"constant":true,"inputs":[{"name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+	{"constant":true,"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"type":"function"},
+	{"constant":true,"inputs":[],"name":"name","outputs":[{"name":"","type":"string"}],"type":"function"},
+	{"constant":true,"inputs":[],"name":"symbol","outputs":[{"name":"","type":"string"}],"type":"function"},
+	{"constant":true,"inputs":[],"name":"totalSupply","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+	{"constant":true,"inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"name":"allowance","outputs":[{"name":"","type":"uint256"}],"type":"function"},
+	{"constant":false,"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"type":"function"},
+	{"constant":false,"inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"type":"function"}
+]`
+
+// erc20Bytes32ABIJSON describes older tokens such as MKR that return name and
+// symbol as bytes32 instead of string
+const erc20Bytes32ABIJSON = `[
+	{"constant":true,"inputs":[],"name":"name","outputs":[{"name":"","type":"bytes32"}],"type":"function"},
+	{"constant":true,"inputs":[],"name":"symbol","outputs":[{"name":"","type":"bytes32"}],"type":"function"}
+]`
+
+var (
+	allowanceToken   string
+	allowanceOwner   string
+	allowanceSpender string
+)
+
+var (
+	erc20ABI        = mustParseABI(erc20ABIJSON)
+	erc20Bytes32ABI = mustParseABI(erc20Bytes32ABIJSON)
+)
+
+// mustParseABI parses a built-in ABI definition, panicking if it is malformed
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// TokenMeta holds the display metadata of an ERC-20 token
+type TokenMeta struct {
+	Symbol   string
+	Decimals uint8
+}
+
+// callMethod packs a contract call, executes it against the latest block and
+// unpacks the returned values
+func (c *Client) callMethod(contract common.Address, contractABI abi.ABI, method string, args ...any) ([]any, error) {
+	return c.CallFunction(contract, contractABI, method, args, nil, nil)
+}
+
+// tokenString reads a string property such as name or symbol, falling back
+// to the bytes32 encoding used by some older tokens
+func (c *Client) tokenString(token common.Address, method string) (string, error) {
+	values, err := c.callMethod(token, erc20ABI, method)
+	if err == nil {
+		return values[0].(string), nil
+	}
+
+	fallback, fallbackErr := c.callMethod(token, erc20Bytes32ABI, method)
+	if fallbackErr != nil {
+		return "", err
+	}
+	raw := fallback[0].([32]byte)
+	return string(bytes.TrimRight(raw[:], "\x00")), nil
+}
+
+// GetTokenMetadata returns an ERC-20 token's name, symbol, decimals and raw
+// total supply
+func (c *Client) GetTokenMetadata(token string) (name, symbol string, decimals uint8, totalSupply *big.Int, err error) {
+	addr, err := c.Resolve(token)
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+
+	if name, err = c.tokenString(addr, "name"); err != nil {
+		return "", "", 0, nil, fmt.Errorf("failed to get token name: %w", err)
+	}
+
+	meta, err := c.GetTokenMeta(addr.Hex())
+	if err != nil {
+		return "", "", 0, nil, err
+	}
+
+	values, err := c.callMethod(addr, erc20ABI, "totalSupply")
+	if err != nil {
+		return "", "", 0, nil, fmt.Errorf("failed to get token total supply: %w", err)
+	}
+	return name, meta.Symbol, meta.Decimals, values[0].(*big.Int), nil
+}
+
+// GetTokenBalance returns the raw ERC-20 balance of holder. Both the token
+// and the holder may be hex addresses or ENS names.
+func (c *Client) GetTokenBalance(token, holder string) (*big.Int, error) {
+	tokenAddr, err := c.Resolve(token)
+	if err != nil {
+		return nil, err
+	}
+	holderAddr, err := c.Resolve(holder)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := c.callMethod(tokenAddr, erc20ABI, "balanceOf", holderAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token balance: %w", err)
+	}
+	return values[0].(*big.Int), nil
+}
+
+// GetTokenAllowance returns the raw amount of an ERC-20 token spender may
+// transfer from owner's balance
+func (c *Client) GetTokenAllowance(token, owner, spender common.Address) (*big.Int, error) {
+	values, err := c.callMethod(token, erc20ABI, "allowance", owner, spender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token allowance: %w", err)
+	}
+	return values[0].(*big.Int), nil
+}
+
+// formatTokenAmount formats a raw token amount in whole tokens, or as
+// "unlimited" for the maximum uint256 that unlimited approvals use
+func formatTokenAmount(amount *big.Int, decimals uint8) string {
+	if amount.Cmp(math.MaxBig256) == 0 {
+		return "unlimited"
+	}
+	return formatUnits(amount, decimals)
+}
+
+// GetTokenBalances returns the raw ERC-20 balances of several holders,
+// batching the balanceOf calls through Multicall3. Holders whose batched call
+// fails, or all of them when multicall is unavailable, are queried one by one.
+func (c *Client) GetTokenBalances(token common.Address, holders []common.Address) ([]*big.Int, error) {
+	calls := make([]Call, len(holders))
+	for i, holder := range holders {
+		data, err := erc20ABI.Pack("balanceOf", holder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack balanceOf: %w", err)
+		}
+		calls[i] = Call{Target: token, CallData: data}
+	}
+
+	balances, errs, multicallErr := c.multicallUint256(calls)
+	if multicallErr != nil {
+		balances = make([]*big.Int, len(holders))
+	}
+	for i, holder := range holders {
+		if multicallErr == nil && errs[i] == nil {
+			continue
+		}
+		balance, err := c.GetTokenBalance(token.Hex(), holder.Hex())
+		if err != nil {
+			return nil, err
+		}
+		balances[i] = balance
+	}
+	return balances, nil
+}
+
+// GetTokenMeta returns a token's symbol and decimals, caching them per token
+// for the lifetime of the client
+func (c *Client) GetTokenMeta(token string) (*TokenMeta, error) {
+	addr, err := c.Resolve(token)
+	if err != nil {
+		return nil, err
+	}
+
+	c.tokensMu.Lock()
+	meta, ok := c.tokens[addr]
+	c.tokensMu.Unlock()
+	if ok {
+		return meta, nil
+	}
+
+	decimals, err := c.callMethod(addr, erc20ABI, "decimals")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token decimals: %w", err)
+	}
+	symbol, err := c.tokenString(addr, "symbol")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token symbol: %w", err)
+	}
+
+	meta = &TokenMeta{Symbol: symbol, Decimals: decimals[0].(uint8)}
+
+	c.tokensMu.Lock()
+	c.tokens[addr] = meta
+	c.tokensMu.Unlock()
+	return meta, nil
+}
+
+// TokenBalanceInfo is a single holder's token balance
+type TokenBalanceInfo struct {
+	Token     string     `json:"token"`
+	Holder    string     `json:"holder"`
+	Symbol    string     `json:"symbol"`
+	Decimals  uint8      `json:"decimals"`
+	Balance   string     `json:"balance"`
+	Formatted string     `json:"formatted"`
+	Fiat      *FiatValue `json:"fiat,omitempty"`
+}
+
+// TokenBalances is the result of the token-balance command
+type TokenBalances []TokenBalanceInfo
+
+func (b TokenBalances) renderText(w io.Writer) {
+	for _, t := range b {
+		balance := t.Formatted + " " + t.Symbol
+		if t.Fiat != nil {
+			balance += fmt.Sprintf(" (%s)", t.Fiat)
+		}
+		fmt.Fprintf(w, "%s %s\n", cyan(t.Holder+":"), green(balance))
+	}
+}
+
+var tokenBalanceCmd = &cobra.Command{
+	Use:   "token-balance [token] [holder...]",
+	Short: "Get ERC-20 token balance for one or more holders",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		token, err := rpcClient.Resolve(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		meta, err := rpcClient.GetTokenMeta(token.Hex())
+		if err != nil {
+			fatal(err)
+		}
+
+		holders := make([]common.Address, 0, len(args)-1)
+		for _, arg := range args[1:] {
+			holder, err := rpcClient.Resolve(arg)
+			if err != nil {
+				fatal(err)
+			}
+			holders = append(holders, holder)
+		}
+
+		amounts, err := rpcClient.GetTokenBalances(token, holders)
+		if err != nil {
+			fatal(err)
+		}
+
+		var (
+			price    *big.Float
+			priceErr error
+		)
+		if fiatCurrency != "" {
+			url, path := priceSource(&token, defaultTokenPriceURL, defaultTokenPricePath)
+			price, priceErr = fetchPrice(rpcClient.ctx, url, path)
+		}
+
+		var balances TokenBalances
+		for i, holder := range holders {
+			balances = append(balances, TokenBalanceInfo{
+				Token:     token.Hex(),
+				Holder:    holder.Hex(),
+				Symbol:    meta.Symbol,
+				Decimals:  meta.Decimals,
+				Balance:   amounts[i].String(),
+				Formatted: formatUnits(amounts[i], meta.Decimals),
+			})
+			if price != nil {
+				balances[i].Fiat = newFiatValue(balances[i].Formatted, price)
+			}
+		}
+
+		render(balances)
+		if priceErr != nil {
+			printPriceNote(priceErr)
+		}
+	},
+}
+
+// TokenInfo is the result of the token-info command
+type TokenInfo struct {
+	Address              string `json:"address"`
+	Name                 string `json:"name"`
+	Symbol               string `json:"symbol"`
+	Decimals             uint8  `json:"decimals"`
+	TotalSupply          string `json:"totalSupply"`
+	TotalSupplyFormatted string `json:"totalSupplyFormatted"`
+}
+
+func (t TokenInfo) renderText(w io.Writer) {
+	printField(w, "Name", t.Name)
+	printField(w, "Symbol", t.Symbol)
+	printField(w, "Decimals", t.Decimals)
+	printField(w, "Total Supply", t.TotalSupplyFormatted+" "+t.Symbol)
+}
+
+var tokenInfoCmd = &cobra.Command{
+	Use:   "token-info [token]",
+	Short: "Show ERC-20 token name, symbol, decimals and total supply",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		token, err := rpcClient.Resolve(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		name, symbol, decimals, totalSupply, err := rpcClient.GetTokenMetadata(token.Hex())
+		if err != nil {
+			fatal(err)
+		}
+
+		render(TokenInfo{
+			Address:              token.Hex(),
+			Name:                 name,
+			Symbol:               symbol,
+			Decimals:             decimals,
+			TotalSupply:          totalSupply.String(),
+			TotalSupplyFormatted: formatUnits(totalSupply, decimals),
+		})
+	},
+}
+
+// TokenAllowanceInfo is the result of the erc20 allowance command
+type TokenAllowanceInfo struct {
+	Token     string `json:"token"`
+	Owner     string `json:"owner"`
+	Spender   string `json:"spender"`
+	Symbol    string `json:"symbol"`
+	Decimals  uint8  `json:"decimals"`
+	Allowance string `json:"allowance"`
+	Formatted string `json:"formatted"`
+}
+
+func (a TokenAllowanceInfo) renderText(w io.Writer) {
+	printField(w, "Owner", a.Owner)
+	printField(w, "Spender", a.Spender)
+	printField(w, "Allowance", a.Formatted+" "+a.Symbol)
+}
+
+var erc20AllowanceCmd = &cobra.Command{
+	Use:   "allowance",
+	Short: "Show how many tokens a spender may transfer on an owner's behalf",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, err := rpcClient.Resolve(allowanceToken)
+		if err != nil {
+			fatal(err)
+		}
+		owner, err := rpcClient.Resolve(allowanceOwner)
+		if err != nil {
+			fatal(err)
+		}
+		spender, err := rpcClient.Resolve(allowanceSpender)
+		if err != nil {
+			fatal(err)
+		}
+
+		meta, err := rpcClient.GetTokenMeta(token.Hex())
+		if err != nil {
+			fatal(err)
+		}
+		allowance, err := rpcClient.GetTokenAllowance(token, owner, spender)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(TokenAllowanceInfo{
+			Token:     token.Hex(),
+			Owner:     owner.Hex(),
+			Spender:   spender.Hex(),
+			Symbol:    meta.Symbol,
+			Decimals:  meta.Decimals,
+			Allowance: allowance.String(),
+			Formatted: formatTokenAmount(allowance, meta.Decimals),
+		})
+	},
+}
+
+func init() {
+	addPriceFlags(tokenBalanceCmd)
+
+	erc20AllowanceCmd.Flags().StringVar(&allowanceToken, "token", "", "Token contract address or ENS name")
+	erc20AllowanceCmd.Flags().StringVar(&allowanceOwner, "owner", "", "Token owner address or ENS name")
+	erc20AllowanceCmd.Flags().StringVar(&allowanceSpender, "spender", "", "Spender address or ENS name, e.g. a DEX router")
+	erc20AllowanceCmd.MarkFlagRequired("token")
+	erc20AllowanceCmd.MarkFlagRequired("owner")
+	erc20AllowanceCmd.MarkFlagRequired("spender")
+	erc20Cmd.AddCommand(erc20AllowanceCmd)
+
+	rootCmd.AddCommand(tokenBalanceCmd)
+	rootCmd.AddCommand(tokenInfoCmd)
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	transferToken  string
+	transferTo     string
+	transferAmount string
+
+	approveToken   string
+	approveSpender string
+	approveAmount  string
+)
+
+// TransferToken sends amount, in the token's smallest unit, of an ERC-20
+// token from the key's address to to and returns the transaction
+func (c *Client) TransferToken(priv *ecdsa.PrivateKey, token, to common.Address, amount *big.Int) (*types.Transaction, error) {
+	data, err := erc20ABI.Pack("transfer", to, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack transfer: %w", err)
+	}
+	return c.sendDynamicFeeTx(priv, &token, new(big.Int), data, 0, nil)
+}
+
+// ApproveToken allows spender to transfer up to amount, in the token's
+// smallest unit, of an ERC-20 token from the key's address and returns the
+// transaction
+func (c *Client) ApproveToken(priv *ecdsa.PrivateKey, token, spender common.Address, amount *big.Int) (*types.Transaction, error) {
+	data, err := erc20ABI.Pack("approve", spender, amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack approve: %w", err)
+	}
+	return c.sendDynamicFeeTx(priv, &token, new(big.Int), data, 0, nil)
+}
+
+// parseTokenAmount parses an amount in whole tokens, or "max" for the
+// maximum uint256 that approvals treat as unlimited
+func parseTokenAmount(s string, decimals uint8) (*big.Int, error) {
+	if strings.EqualFold(s, "max") {
+		return new(big.Int).Set(math.MaxBig256), nil
+	}
+	amount, err := parseUnits(s, decimals)
+	if err != nil {
+		return nil, err
+	}
+	if amount.Cmp(math.MaxBig256) > 0 {
+		return nil, fmt.Errorf("amount %q exceeds the maximum uint256", s)
+	}
+	return amount, nil
+}
+
+// TokenTxResult is the result of a mined ERC-20 transaction: a transfer to To
+// or an approval of To as spender. Amount is in the token's smallest unit and
+// Formatted in whole tokens.
+type TokenTxResult struct {
+	Hash        string `json:"hash"`
+	Method      string `json:"method"`
+	Token       string `json:"token"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Symbol      string `json:"symbol"`
+	Amount      string `json:"amount"`
+	Formatted   string `json:"formatted"`
+	Status      string `json:"status"`
+	BlockNumber uint64 `json:"blockNumber"`
+	GasUsed     uint64 `json:"gasUsed"`
+}
+
+func (r TokenTxResult) renderText(w io.Writer) {
+	printField(w, "Transaction", r.Hash)
+	if r.Method == "approve" {
+		printField(w, "Approve", fmt.Sprintf("%s %s for %s", r.Formatted, r.Symbol, r.To))
+	} else {
+		printField(w, "Transfer", fmt.Sprintf("%s %s to %s", r.Formatted, r.Symbol, r.To))
+	}
+	fmt.Fprintf(w, "%s %s\n", cyan("Status:"), statusString(r.Status))
+	printField(w, "Block", r.BlockNumber)
+	printField(w, "Gas Used", r.GasUsed)
+}
+
+var erc20Cmd = &cobra.Command{
+	Use:   "erc20",
+	Short: "Transfer ERC-20 tokens and manage allowances",
+}
+
+var erc20TransferCmd = &cobra.Command{
+	Use:   "transfer",
+	Short: "Transfer ERC-20 tokens and wait for the receipt",
+	Long: `Sends an ERC-20 transfer from the signing key's address and waits for it to be
+mined. --amount is in whole tokens, e.g. 1.5, and is scaled by the token's
+decimals. The sender's token balance is checked first and a warning printed if
+it is too low; such a transfer reverts when its gas is estimated. Exits
+non-zero if the transfer reverted.`,
+	Annotations: map[string]string{annotationLongRunning: ""},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, err := rpcClient.Resolve(transferToken)
+		if err != nil {
+			fatal(err)
+		}
+		to, err := rpcClient.Resolve(transferTo)
+		if err != nil {
+			fatal(err)
+		}
+
+		meta, err := rpcClient.GetTokenMeta(token.Hex())
+		if err != nil {
+			fatal(err)
+		}
+		amount, err := parseUnits(transferAmount, meta.Decimals)
+		if err != nil {
+			fatal(err)
+		}
+
+		priv, err := loadSigningKey(cmd)
+		if err != nil {
+			fatal(err)
+		}
+		defer zeroKey(priv)
+		from := crypto.PubkeyToAddress(priv.PublicKey)
+
+		balance, err := rpcClient.GetTokenBalance(token.Hex(), from.Hex())
+		if err != nil {
+			fatal(err)
+		}
+		if balance.Cmp(amount) < 0 {
+			fmt.Fprintln(progressWriter(), red(fmt.Sprintf("Warning: %s holds %s %s, less than the %s %s being sent",
+				from.Hex(), formatUnits(balance, meta.Decimals), meta.Symbol, formatUnits(amount, meta.Decimals), meta.Symbol)))
+		}
+
+		tx, err := rpcClient.TransferToken(priv, token, to, amount)
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Fprintf(progressWriter(), "Waiting for %s to be mined...\n", tx.Hash().Hex())
+
+		receipt, err := rpcClient.WaitMined(tx.Hash(), 0)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(TokenTxResult{
+			Hash:        tx.Hash().Hex(),
+			Method:      "transfer",
+			Token:       token.Hex(),
+			From:        from.Hex(),
+			To:          to.Hex(),
+			Symbol:      meta.Symbol,
+			Amount:      amount.String(),
+			Formatted:   formatUnits(amount, meta.Decimals),
+			Status:      receiptStatus(receipt.Status),
+			BlockNumber: receipt.BlockNumber.Uint64(),
+			GasUsed:     receipt.GasUsed,
+		})
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			exit(1)
+		}
+	},
+}
+
+var erc20ApproveCmd = &cobra.Command{
+	Use:   "approve",
+	Short: "Allow a spender to transfer ERC-20 tokens and wait for the receipt",
+	Long: `Sends an ERC-20 approve from the signing key's address, allowing --spender,
+e.g. a DEX router, to transfer up to --amount of its tokens, and waits for it
+to be mined. --amount is in whole tokens and scaled by the token's decimals;
+"max" approves 2^256-1, which most tokens treat as unlimited, and 0 revokes the
+approval. Some tokens, such as USDT, only accept a new non-zero approval once
+the current one has been set to 0. Exits non-zero if the approval reverted.`,
+	Annotations: map[string]string{annotationLongRunning: ""},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, err := rpcClient.Resolve(approveToken)
+		if err != nil {
+			fatal(err)
+		}
+		spender, err := rpcClient.Resolve(approveSpender)
+		if err != nil {
+			fatal(err)
+		}
+
+		meta, err := rpcClient.GetTokenMeta(token.Hex())
+		if err != nil {
+			fatal(err)
+		}
+		amount, err := parseTokenAmount(approveAmount, meta.Decimals)
+		if err != nil {
+			fatal(err)
+		}
+
+		priv, err := loadSigningKey(cmd)
+		if err != nil {
+			fatal(err)
+		}
+		defer zeroKey(priv)
+
+		tx, err := rpcClient.ApproveToken(priv, token, spender, amount)
+		if err != nil {
+			fatal(err)
+		}
+		fmt.Fprintf(progressWriter(), "Waiting for %s to be mined...\n", tx.Hash().Hex())
+
+		receipt, err := rpcClient.WaitMined(tx.Hash(), 0)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(TokenTxResult{
+			Hash:        tx.Hash().Hex(),
+			Method:      "approve",
+			Token:       token.Hex(),
+			From:        crypto.PubkeyToAddress(priv.PublicKey).Hex(),
+			To:          spender.Hex(),
+			Symbol:      meta.Symbol,
+			Amount:      amount.String(),
+			Formatted:   formatTokenAmount(amount, meta.Decimals),
+			Status:      receiptStatus(receipt.Status),
+			BlockNumber: receipt.BlockNumber.Uint64(),
+			GasUsed:     receipt.GasUsed,
+		})
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	erc20TransferCmd.Flags().StringVar(&transferToken, "token", "", "Token contract address or ENS name")
+	erc20TransferCmd.Flags().StringVar(&transferTo, "to", "", "Recipient address or ENS name")
+	erc20TransferCmd.Flags().StringVar(&transferAmount, "amount", "", "Amount in whole tokens, e.g. 1.5")
+	addKeyFlags(erc20TransferCmd)
+	erc20TransferCmd.MarkFlagRequired("token")
+	erc20TransferCmd.MarkFlagRequired("to")
+	erc20TransferCmd.MarkFlagRequired("amount")
+
+	erc20ApproveCmd.Flags().StringVar(&approveToken, "token", "", "Token contract address or ENS name")
+	erc20ApproveCmd.Flags().StringVar(&approveSpender, "spender", "", "Spender address or ENS name, e.g. a DEX router")
+	erc20ApproveCmd.Flags().StringVar(&approveAmount, "amount", "", `Amount in whole tokens, e.g. 1.5, or "max" for unlimited`)
+	addKeyFlags(erc20ApproveCmd)
+	erc20ApproveCmd.MarkFlagRequired("token")
+	erc20ApproveCmd.MarkFlagRequired("spender")
+	erc20ApproveCmd.MarkFlagRequired("amount")
+
+	erc20Cmd.AddCommand(erc20TransferCmd)
+	erc20Cmd.AddCommand(erc20ApproveCmd)
+	rootCmd.AddCommand(erc20Cmd)
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var (
+	estimateFrom  string
+	estimateTo    string
+	estimateValue string
+	estimateData  string
+)
+
+// EstimateGas estimates the gas needed for a transaction. from may be empty,
+// and an empty to estimates a contract creation. A revert is reported with
+// its decoded reason when the node returns one.
+func (c *Client) EstimateGas(from, to string, value *big.Int, data []byte) (uint64, error) {
+	msg := ethereum.CallMsg{Value: value, Data: data}
+	if from != "" {
+		addr, err := c.Resolve(from)
+		if err != nil {
+			return 0, err
+		}
+		msg.From = addr
+	}
+	if to != "" {
+		addr, err := c.Resolve(to)
+		if err != nil {
+			return 0, err
+		}
+		msg.To = &addr
+	}
+
+	var gas uint64
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		gas, err = ec.EstimateGas(c.ctx, msg)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate gas: %w", wrapRevert(err))
+	}
+	return gas, nil
+}
+
+// GasEstimate is the result of the estimate-gas command. Amounts are in wei.
+// GasPrice is the price the transaction is expected to pay: baseFee + tip on
+// EIP-1559 chains, which also set the fee fields and MaxCost, and the node's
+// suggested gas price on other chains.
+type GasEstimate struct {
+	Gas                  uint64 `json:"gas"`
+	GasPrice             string `json:"gasPrice"`
+	BaseFee              string `json:"baseFee,omitempty"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         string `json:"maxFeePerGas,omitempty"`
+	EstimatedCost        string `json:"estimatedCost"`
+	MaxCost              string `json:"maxCost,omitempty"`
+}
+
+func (g GasEstimate) renderText(w io.Writer) {
+	unit := gasPriceUnit()
+	printField(w, "Gas", g.Gas)
+	if g.MaxFeePerGas == "" {
+		printField(w, "Gas Price", formatAmount(parseWei(g.GasPrice), unit))
+		printField(w, "Estimated Cost", formatAmount(parseWei(g.EstimatedCost), amountUnit))
+		fmt.Fprintln(w, "Note: chain has no EIP-1559 fee market; showing the node's suggested gas price")
+		return
+	}
+	printField(w, "Base Fee", formatAmount(parseWei(g.BaseFee), unit))
+	printField(w, "Max Priority Fee", formatAmount(parseWei(g.MaxPriorityFeePerGas), unit))
+	printField(w, "Max Fee", formatAmount(parseWei(g.MaxFeePerGas), unit))
+	printField(w, "Estimated Cost", formatAmount(parseWei(g.EstimatedCost), amountUnit))
+	printField(w, "Max Cost", formatAmount(parseWei(g.MaxCost), amountUnit))
+}
+
+var estimateGasCmd = &cobra.Command{
+	Use:   "estimate-gas",
+	Short: "Estimate the gas and cost of a transaction",
+	Long: `Estimates the gas a transaction would use and its cost at current prices.
+On EIP-1559 chains it also prints the suggested maxPriorityFeePerGas (tip) and
+maxFeePerGas (baseFee*2 + tip), everything needed to build a type-2
+transaction; the estimated cost uses baseFee + tip and the max cost
+maxFeePerGas. Other chains show the suggested gas price. If the transaction
+would revert, the decoded revert reason is reported instead.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		value := new(big.Int)
+		if estimateValue != "" {
+			var err error
+			if value, err = parseUnits(estimateValue, 18); err != nil {
+				fatal(err)
+			}
+		}
+
+		var data []byte
+		if estimateData != "" {
+			var err error
+			if data, err = hexutil.Decode(estimateData); err != nil {
+				fatalf("invalid --data: %v", err)
+			}
+		}
+
+		gas, err := rpcClient.EstimateGas(estimateFrom, estimateTo, value, data)
+		if err != nil {
+			fatal(err)
+		}
+
+		fees, err := rpcClient.EstimateFees()
+		if err != nil {
+			fatal(err)
+		}
+
+		gasUnits := new(big.Int).SetUint64(gas)
+		price := fees.GasPrice
+		if fees.BaseFee != nil {
+			price = new(big.Int).Add(fees.BaseFee, fees.TipCap)
+		}
+
+		estimate := GasEstimate{
+			Gas:           gas,
+			GasPrice:      price.String(),
+			EstimatedCost: new(big.Int).Mul(gasUnits, price).String(),
+		}
+		if fees.MaxFeePerGas != nil {
+			estimate.BaseFee = fees.BaseFee.String()
+			estimate.MaxPriorityFeePerGas = fees.TipCap.String()
+			estimate.MaxFeePerGas = fees.MaxFeePerGas.String()
+			estimate.MaxCost = new(big.Int).Mul(gasUnits, fees.MaxFeePerGas).String()
+		}
+
+		render(estimate)
+	},
+}
+
+func init() {
+	estimateGasCmd.Flags().StringVar(&estimateFrom, "from", "", "Sender address or ENS name")
+	estimateGasCmd.Flags().StringVar(&estimateTo, "to", "", "Recipient address or ENS name (omit for contract creation)")
+	estimateGasCmd.Flags().StringVar(&estimateValue, "value", "", "Value to send in ETH, e.g. 0.1")
+	estimateGasCmd.Flags().StringVar(&estimateData, "data", "", "Hex-encoded calldata")
+
+	rootCmd.AddCommand(estimateGasCmd)
+}
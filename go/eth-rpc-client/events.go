@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+// Delays between attempts to re-subscribe after a log subscription drops,
+// doubling from the first to the second
+const (
+	resubscribeDelay    = time.Second
+	maxResubscribeDelay = 30 * time.Second
+)
+
+// FollowLogs streams the logs matching query to fn until the client's context
+// is done. When the subscription drops it re-subscribes with backoff, and the
+// logs of blocks mined while disconnected are fetched with eth_getLogs, so
+// none are missed. Only failing to subscribe in the first place is an error.
+func (c *Client) FollowLogs(query ethereum.FilterQuery, fn func(types.Log) error) error {
+	var (
+		covered     uint64 // highest block whose logs have been delivered
+		backfilled  uint64 // subscribed logs up to here were delivered by the backfill
+		reconnected bool
+		delay       = resubscribeDelay
+	)
+	for {
+		ch := make(chan types.Log)
+		sub, err := c.SubscribeLogs(query, ch)
+		if err == nil {
+			var head uint64
+			if head, err = c.GetBlockNumber(); err == nil && reconnected && head > covered {
+				fmt.Fprintf(progressWriter(), "Note: fetching logs of blocks %d-%d mined while disconnected\n", covered+1, head)
+				err = c.GetLogsChunked(query, covered+1, head, logsChunkSize, fn)
+			}
+			if err == nil {
+				covered = max(covered, head)
+				if reconnected {
+					backfilled = head
+				}
+			} else {
+				sub.Unsubscribe()
+			}
+		}
+		if err != nil {
+			if !reconnected || c.ctx.Err() != nil {
+				return err
+			}
+			fmt.Fprintf(progressWriter(), "Note: re-subscribing failed (%v); retrying in %s\n", err, delay)
+			c.sleep(delay)
+			delay = min(delay*2, maxResubscribeDelay)
+			continue
+		}
+		delay = resubscribeDelay
+
+		err = func() error {
+			defer sub.Unsubscribe()
+			for {
+				select {
+				case <-c.ctx.Done():
+					return nil
+				case err := <-sub.Err():
+					fmt.Fprintf(progressWriter(), "Note: subscription dropped (%v); re-subscribing\n", err)
+					return nil
+				case l := <-ch:
+					if l.BlockNumber <= backfilled && !l.Removed {
+						continue
+					}
+					covered = max(covered, l.BlockNumber)
+					if err := fn(l); err != nil {
+						return err
+					}
+				}
+			}
+		}()
+		if err != nil || c.ctx.Err() != nil {
+			return err
+		}
+		reconnected = true
+	}
+}
+
+// ContractEvent is a log printed by the events command. Error is set instead
+// of Event and Args when the log does not match an event in the ABI.
+type ContractEvent struct {
+	BlockNumber uint64     `json:"blockNumber"`
+	TxHash      string     `json:"transactionHash"`
+	LogIndex    uint       `json:"logIndex"`
+	Event       string     `json:"event,omitempty"`
+	Args        []ABIValue `json:"args,omitempty"`
+	Removed     bool       `json:"removed,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// newContractEvent decodes a log against the contract's ABI
+func newContractEvent(contractABI abi.ABI, l types.Log) ContractEvent {
+	event := ContractEvent{
+		BlockNumber: l.BlockNumber,
+		TxHash:      l.TxHash.Hex(),
+		LogIndex:    l.Index,
+		Removed:     l.Removed,
+	}
+	decoded, err := decodeLog(contractABI, &l)
+	if err != nil {
+		event.Error = err.Error()
+		return event
+	}
+	event.Event, event.Args = decoded.Event, decoded.Args
+	return event
+}
+
+// String formats the event as a call, e.g. Transfer(from=0x..., to=0x..., value=1)
+func (e ContractEvent) String() string {
+	if e.Error != "" {
+		return e.Error
+	}
+	name, _, _ := strings.Cut(e.Event, "(")
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		label := arg.Name
+		if label == "" {
+			label = fmt.Sprintf("arg%d", i)
+		}
+		args[i] = label + "=" + arg.Value
+	}
+	return name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (e ContractEvent) renderText(w io.Writer) {
+	line := e.String()
+	if e.Removed {
+		line += " (removed by reorg)"
+	}
+	fmt.Fprintf(w, "%s %s %s\n", cyan(fmt.Sprintf("#%d", e.BlockNumber)), green(line), e.TxHash)
+}
+
+var eventsCmd = &cobra.Command{
+	Use:   "events [contract]",
+	Short: "Follow a contract's events, decoded with its ABI",
+	Long: `Subscribes to every log the contract emits and prints each one as it is
+mined, decoded with the events in --abi, e.g.
+
+  #19000000 Transfer(from=0x..., to=0x..., value=1000000) 0x<tx hash>
+
+Requires a websocket or IPC endpoint. When the subscription drops, the command
+re-subscribes automatically and fetches the logs of any blocks mined in the
+meantime, so no events are missed. Logs that match no event in the ABI are
+printed with the reason they could not be decoded.`,
+	Annotations: map[string]string{annotationLongRunning: "", annotationStreaming: ""},
+	Args:        cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := requireSubscriptions(rpcURLs); err != nil {
+			fatal(err)
+		}
+
+		contract, err := rpcClient.Resolve(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		contractABI, err := rpcClient.ContractABI(contract)
+		if err != nil {
+			fatal(err)
+		}
+		if len(contractABI.Events) == 0 {
+			fatalf("the ABI of %s has no events", contract.Hex())
+		}
+
+		query := ethereum.FilterQuery{Addresses: []common.Address{contract}}
+		err = rpcClient.FollowLogs(query, func(l types.Log) error {
+			renderEvent(newContractEvent(contractABI, l))
+			return nil
+		})
+		if err != nil {
+			fatal(err)
+		}
+	},
+}
+
+func init() {
+	addABIFlags(eventsCmd, "JSON ABI or Hardhat/Foundry artifact with the contract's events, as a file or inline JSON")
+	eventsCmd.MarkFlagsOneRequired("abi", "abi-etherscan")
+
+	rootCmd.AddCommand(eventsCmd)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"os"
+	"sync"
+)
+
+// exitHooks release what a command holds — the RPC client, the --out file,
+// signing keys — when it finishes. Commands exit through fatal and exit, which
+// run the hooks first, since os.Exit skips deferred calls and cobra's
+// PersistentPostRun.
+var (
+	exitHooksMu sync.Mutex
+	exitHooks   []func() error
+)
+
+// onExit registers a hook to run when the command finishes or exits
+func onExit(hook func() error) {
+	exitHooksMu.Lock()
+	defer exitHooksMu.Unlock()
+	exitHooks = append(exitHooks, hook)
+}
+
+// runExitHooks runs the registered hooks once, most recent first, and returns
+// their errors
+func runExitHooks() error {
+	exitHooksMu.Lock()
+	hooks := exitHooks
+	exitHooks = nil
+	exitHooksMu.Unlock()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// exit runs the exit hooks and exits with code, or 1 if a hook failed
+func exit(code int) {
+	if err := runExitHooks(); err != nil {
+		log.Print(err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+// fatal logs v like log.Fatal and exits through the exit hooks
+func fatal(v ...any) {
+	log.Print(v...)
+	exit(1)
+}
+
+// fatalf logs like log.Fatalf and exits through the exit hooks
+func fatalf(format string, v ...any) {
+	log.Printf(format, v...)
+	exit(1)
+}
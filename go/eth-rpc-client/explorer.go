@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// builtinExplorers maps chain IDs to the base URL of their Etherscan-style
+// block explorer. Entries under explorers in the config file take precedence.
+var builtinExplorers = map[uint64]string{
+	1:        "https://etherscan.io",
+	10:       "https://optimistic.etherscan.io",
+	56:       "https://bscscan.com",
+	100:      "https://gnosisscan.io",
+	137:      "https://polygonscan.com",
+	250:      "https://ftmscan.com",
+	8453:     "https://basescan.org",
+	17000:    "https://holesky.etherscan.io",
+	42161:    "https://arbiscan.io",
+	43114:    "https://snowtrace.io",
+	59144:    "https://lineascan.build",
+	534352:   "https://scrollscan.com",
+	11155111: "https://sepolia.etherscan.io",
+}
+
+var (
+	showExplorer bool
+
+	// configExplorers holds the explorers section of the config file
+	configExplorers map[uint64]string
+)
+
+// addExplorerFlag registers --explorer on a command whose output can link to
+// a block explorer
+func addExplorerFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&showExplorer, "explorer", false, "Include a block explorer link")
+}
+
+// explorerLink returns the explorer URL for a path such as "/tx/<hash>",
+// "/block/<n>" or "/address/<addr>" on the given chain
+func explorerLink(chainID *big.Int, path string) (string, error) {
+	id := chainID.Uint64()
+	base, ok := configExplorers[id]
+	if !ok {
+		base, ok = builtinExplorers[id]
+	}
+	if !ok {
+		return "", fmt.Errorf("no block explorer known for chain ID %s; add one under explorers in the config file", chainID)
+	}
+	return strings.TrimSuffix(base, "/") + path, nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// csvRecorder is implemented by events that can be exported to a CSV file one
+// row at a time
+type csvRecorder interface {
+	csvRecord() []string
+}
+
+// fileExporter streams events to an --export file as they are produced, so
+// exporting a long range never holds it in memory. The format follows the
+// file's extension: .json writes a JSON array with one event per line, .csv
+// one row per event after a header.
+type fileExporter struct {
+	path   string
+	f      *os.File
+	buf    *bufio.Writer
+	csv    *csv.Writer
+	count  int
+	closed bool
+}
+
+// createExporter creates or truncates the file at path for exporting events.
+// header names the CSV columns.
+func createExporter(path string, header []string) (*fileExporter, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".json" && ext != ".csv" {
+		return nil, fmt.Errorf("invalid --export %q: the file name must end in .json or .csv", path)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	e := &fileExporter{path: path, f: f, buf: bufio.NewWriter(f)}
+	if ext == ".csv" {
+		e.csv = csv.NewWriter(e.buf)
+		e.csv.Write(header)
+	} else {
+		e.buf.WriteString("[")
+	}
+	// A command that fails partway exits through fatal, which skips its
+	// deferred close, so the events written so far are flushed by a hook
+	onExit(e.close)
+	return e, nil
+}
+
+// write appends one event to the file
+func (e *fileExporter) write(v csvRecorder) error {
+	if e.csv != nil {
+		if err := e.csv.Write(v.csvRecord()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", e.path, err)
+		}
+	} else {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		if e.count > 0 {
+			e.buf.WriteString(",")
+		}
+		e.buf.WriteString("\n")
+		if _, err := e.buf.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", e.path, err)
+		}
+	}
+	e.count++
+	return nil
+}
+
+// close finishes the file. Only the first call has an effect.
+func (e *fileExporter) close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			e.f.Close()
+			return fmt.Errorf("failed to write %s: %w", e.path, err)
+		}
+	} else {
+		e.buf.WriteString("\n]\n")
+	}
+	if err := e.buf.Flush(); err != nil {
+		e.f.Close()
+		return fmt.Errorf("failed to write %s: %w", e.path, err)
+	}
+	return e.f.Close()
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	finalityConfirmations uint64
+	finalityInterval      time.Duration
+)
+
+// Finality stages reported while waiting for a transaction
+const (
+	StageIncluded     = "included"
+	StageConfirmation = "confirmation"
+	StageFinalized    = "finalized"
+)
+
+// FinalityProgress describes how far a transaction has progressed towards finality
+type FinalityProgress struct {
+	Stage         string
+	Receipt       *types.Receipt
+	Confirmations uint64
+	Finalized     *big.Int
+}
+
+// isFinalizedTagUnsupported reports whether an error means the node does not
+// know the finalized block tag: the method is unavailable, the tag is rejected
+// as an invalid parameter, or the chain has no finalized block. Rate limits,
+// server errors and timeouts are not, so they cannot pass for finality.
+func isFinalizedTagUnsupported(err error) bool {
+	if errors.Is(err, ethereum.NotFound) || isMethodNotFound(err) {
+		return true
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32602 {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unknown block") ||
+		strings.Contains(msg, "invalid block") ||
+		strings.Contains(msg, "finalized block not found")
+}
+
+// GetFinalizedBlockNumber returns the number of the chain's finalized block.
+// It reports ok=false when the node does not support the finalized block tag.
+func (c *Client) GetFinalizedBlockNumber() (*big.Int, bool, error) {
+	var header *types.Header
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		header, err = ec.HeaderByNumber(c.ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+		return err
+	})
+	if err != nil {
+		if isFinalizedTagUnsupported(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get finalized block: %w", err)
+	}
+	return header.Number, true, nil
+}
+
+// WaitFinalized polls until the transaction is included and then until the
+// finalized block reaches the block it was included in. On chains without a
+// finalized tag it falls back to waiting for the given number of confirmations.
+// A receipt that disappears because of a reorg sends it back to waiting for inclusion.
+// Like WaitMined, it returns errTxUnknown or errTxDropped when the node no
+// longer knows the transaction.
+func (c *Client) WaitFinalized(hash common.Hash, confirmations uint64, interval time.Duration, progress func(FinalityProgress)) (*types.Receipt, error) {
+	var (
+		included  common.Hash
+		lastDepth uint64
+		seen      bool
+	)
+
+	for ; ; c.sleep(interval) {
+		if err := c.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var receipt *types.Receipt
+		err := c.withRetry(func(ec *ethclient.Client) (err error) {
+			receipt, err = ec.TransactionReceipt(c.ctx, hash)
+			return err
+		})
+		if errors.Is(err, ethereum.NotFound) {
+			if err := c.checkPending(hash, seen); err != nil {
+				return nil, err
+			}
+			seen = true
+			included = common.Hash{}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receipt: %w", err)
+		}
+		seen = true
+
+		if receipt.BlockHash != included {
+			included = receipt.BlockHash
+			lastDepth = 0
+			progress(FinalityProgress{Stage: StageIncluded, Receipt: receipt})
+		}
+
+		finalized, supported, err := c.GetFinalizedBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		if supported && finalized.Cmp(receipt.BlockNumber) >= 0 {
+			progress(FinalityProgress{Stage: StageFinalized, Receipt: receipt, Finalized: finalized})
+			return receipt, nil
+		}
+
+		head, err := c.GetBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+
+		var depth uint64
+		if head >= receipt.BlockNumber.Uint64() {
+			depth = head - receipt.BlockNumber.Uint64()
+		}
+		if depth != lastDepth {
+			lastDepth = depth
+			progress(FinalityProgress{Stage: StageConfirmation, Receipt: receipt, Confirmations: depth})
+		}
+
+		if !supported && depth >= confirmations {
+			progress(FinalityProgress{Stage: StageFinalized, Receipt: receipt})
+			return receipt, nil
+		}
+	}
+}
+
+// FinalityResult is the result of the wait-finalized command
+type FinalityResult struct {
+	Hash           string `json:"hash"`
+	BlockNumber    uint64 `json:"blockNumber"`
+	BlockHash      string `json:"blockHash"`
+	FinalizedBlock uint64 `json:"finalizedBlock,omitempty"`
+	Status         string `json:"status"`
+}
+
+func (r FinalityResult) renderText(w io.Writer) {
+	fmt.Fprintf(w, "%s %s\n", cyan("Status:"), statusString(r.Status))
+}
+
+var waitFinalizedCmd = &cobra.Command{
+	Use:   "wait-finalized [hash]",
+	Short: "Wait until a transaction is included and finalized",
+	Long: `Polls until the transaction is included in a block and the chain's finalized
+block has reached it, so it can no longer be reorged. Chains without a finalized
+block tag fall back to waiting for --confirmations blocks. Reports a transaction
+the node does not know or that was dropped from the mempool. Exits non-zero if
+the transaction reverted.`,
+	Annotations: map[string]string{annotationLongRunning: ""},
+	Args:        cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		hash, err := parseHash(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		progress := progressWriter()
+		result := FinalityResult{Hash: hash.Hex()}
+		receipt, err := rpcClient.WaitFinalized(hash, finalityConfirmations, finalityInterval, func(p FinalityProgress) {
+			switch p.Stage {
+			case StageIncluded:
+				printField(progress, "Included in block", p.Receipt.BlockNumber)
+			case StageConfirmation:
+				printField(progress, "Confirmations", p.Confirmations)
+			case StageFinalized:
+				if p.Finalized != nil {
+					result.FinalizedBlock = p.Finalized.Uint64()
+					printField(progress, "Finalized at block", p.Finalized)
+				} else {
+					printField(progress, "Finalized", fmt.Sprintf("%d confirmations (no finalized tag)", finalityConfirmations))
+				}
+			}
+		})
+		if err != nil {
+			fatal(err)
+		}
+
+		result.BlockNumber = receipt.BlockNumber.Uint64()
+		result.BlockHash = receipt.BlockHash.Hex()
+		result.Status = receiptStatus(receipt.Status)
+
+		render(result)
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	waitFinalizedCmd.Flags().Uint64Var(&finalityConfirmations, "confirmations", 12, "Confirmations to wait for when the chain has no finalized tag")
+	waitFinalizedCmd.Flags().DurationVar(&finalityInterval, "interval", 4*time.Second, "Polling interval")
+
+	rootCmd.AddCommand(waitFinalizedCmd)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gasOracleBlocks      uint64
+	gasOraclePercentiles []float64
+)
+
+// FeeHistory returns the base fees and the priority fees paid at the given
+// percentiles over the last blocks blocks (eth_feeHistory)
+func (c *Client) FeeHistory(blocks uint64, percentiles []float64) (*ethereum.FeeHistory, error) {
+	var history *ethereum.FeeHistory
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		history, err = ec.FeeHistory(c.ctx, blocks, nil, percentiles)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee history: %w", err)
+	}
+	return history, nil
+}
+
+// FeeSuggestion is a suggested priority fee at one reward percentile. Amounts
+// are in wei; MaxFeePerGas is baseFee*2 + priority fee, as in gasprice.
+type FeeSuggestion struct {
+	Label                string  `json:"label"`
+	Percentile           float64 `json:"percentile"`
+	MaxPriorityFeePerGas string  `json:"maxPriorityFeePerGas"`
+	MaxFeePerGas         string  `json:"maxFeePerGas"`
+}
+
+// GasOracle is the result of the gas-oracle command. On chains without
+// EIP-1559 only GasPrice is set.
+type GasOracle struct {
+	Blocks      uint64          `json:"blocks,omitempty"`
+	BaseFee     string          `json:"baseFee,omitempty"`
+	Suggestions []FeeSuggestion `json:"suggestions,omitempty"`
+	GasPrice    string          `json:"gasPrice,omitempty"`
+}
+
+func (g GasOracle) renderText(w io.Writer) {
+	unit := gasPriceUnit()
+	if g.GasPrice != "" {
+		printField(w, "Gas Price", formatAmount(parseWei(g.GasPrice), unit))
+		fmt.Fprintln(w, "Note: chain has no EIP-1559 fee market; showing the node's suggested gas price")
+		return
+	}
+
+	printField(w, "Base Fee (next block)", formatAmount(parseWei(g.BaseFee), unit))
+	for _, s := range g.Suggestions {
+		printField(w, s.Label, fmt.Sprintf("%s priority, %s max",
+			formatAmount(parseWei(s.MaxPriorityFeePerGas), unit),
+			formatAmount(parseWei(s.MaxFeePerGas), unit)))
+	}
+	fmt.Fprintf(w, "Based on the last %d blocks\n", g.Blocks)
+}
+
+// suggestionLabel names a percentile: slow, standard and fast when exactly
+// three percentiles are requested, otherwise pN
+func suggestionLabel(i int, percentiles []float64) string {
+	if len(percentiles) == 3 {
+		return []string{"Slow", "Standard", "Fast"}[i]
+	}
+	return fmt.Sprintf("p%g", percentiles[i])
+}
+
+// newGasOracle averages the priority fees paid at each percentile over the
+// fee history window. The last base fee in the history is the next block's.
+func newGasOracle(history *ethereum.FeeHistory, percentiles []float64) GasOracle {
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+	oracle := GasOracle{
+		Blocks:  uint64(len(history.Reward)),
+		BaseFee: baseFee.String(),
+	}
+
+	for i, percentile := range percentiles {
+		sum, samples := new(big.Int), int64(0)
+		for _, rewards := range history.Reward {
+			if i < len(rewards) {
+				sum.Add(sum, rewards[i])
+				samples++
+			}
+		}
+		tip := new(big.Int)
+		if samples > 0 {
+			tip.Div(sum, big.NewInt(samples))
+		}
+
+		maxFee := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tip)
+		oracle.Suggestions = append(oracle.Suggestions, FeeSuggestion{
+			Label:                suggestionLabel(i, percentiles),
+			Percentile:           percentile,
+			MaxPriorityFeePerGas: tip.String(),
+			MaxFeePerGas:         maxFee.String(),
+		})
+	}
+	return oracle
+}
+
+var gasOracleCmd = &cobra.Command{
+	Use:   "gas-oracle",
+	Short: "Suggest slow/standard/fast priority fees from recent blocks",
+	Long: `Reads eth_feeHistory for the last --blocks blocks and averages the priority
+fees paid at each of --percentiles (default 10, 50 and 90, shown as slow,
+standard and fast), together with the next block's base fee. Chains without
+EIP-1559 fall back to the node's suggested gas price.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if gasOracleBlocks == 0 {
+			fatal("--blocks must be at least 1")
+		}
+		for i, p := range gasOraclePercentiles {
+			if p < 0 || p > 100 {
+				fatalf("invalid percentile %g (must be between 0 and 100)", p)
+			}
+			if i > 0 && p <= gasOraclePercentiles[i-1] {
+				fatal("--percentiles must be in ascending order")
+			}
+		}
+
+		history, err := rpcClient.FeeHistory(gasOracleBlocks, gasOraclePercentiles)
+		if err != nil && !isMethodNotFound(err) {
+			fatal(err)
+		}
+		if err == nil && len(history.BaseFee) > 0 && history.BaseFee[len(history.BaseFee)-1].Sign() > 0 {
+			render(newGasOracle(history, gasOraclePercentiles))
+			return
+		}
+
+		price, err := rpcClient.SuggestGasPrice()
+		if err != nil {
+			fatal(err)
+		}
+		render(GasOracle{GasPrice: price.String()})
+	},
+}
+
+func init() {
+	gasOracleCmd.Flags().Uint64Var(&gasOracleBlocks, "blocks", 20, "Number of recent blocks to sample")
+	gasOracleCmd.Flags().Float64SliceVar(&gasOraclePercentiles, "percentiles", []float64{10, 50, 90}, "Reward percentiles to report, ascending")
+
+	rootCmd.AddCommand(gasOracleCmd)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// FeeEstimate holds the node's current fee suggestions. BaseFee and
+// MaxFeePerGas are nil on chains without EIP-1559.
+type FeeEstimate struct {
+	GasPrice     *big.Int
+	TipCap       *big.Int
+	BaseFee      *big.Int
+	MaxFeePerGas *big.Int
+}
+
+// SuggestGasPrice returns the node's suggested legacy gas price
+func (c *Client) SuggestGasPrice() (*big.Int, error) {
+	var price *big.Int
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		price, err = ec.SuggestGasPrice(c.ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest gas price: %w", err)
+	}
+	return price, nil
+}
+
+// SuggestGasTipCap returns the node's suggested priority fee
+func (c *Client) SuggestGasTipCap() (*big.Int, error) {
+	var tip *big.Int
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		tip, err = ec.SuggestGasTipCap(c.ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to suggest gas tip cap: %w", err)
+	}
+	return tip, nil
+}
+
+// EstimateFees returns the suggested gas price and, when the latest block has
+// a base fee, an EIP-1559 maxFeePerGas of baseFee*2 + tip
+func (c *Client) EstimateFees() (*FeeEstimate, error) {
+	price, err := c.SuggestGasPrice()
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &FeeEstimate{GasPrice: price}
+
+	var header *types.Header
+	err = c.withRetry(func(ec *ethclient.Client) (err error) {
+		header, err = ec.HeaderByNumber(c.ctx, nil)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	if header.BaseFee == nil {
+		return estimate, nil
+	}
+
+	tip, err := c.SuggestGasTipCap()
+	if err != nil {
+		return nil, err
+	}
+
+	estimate.TipCap = tip
+	estimate.BaseFee = header.BaseFee
+	estimate.MaxFeePerGas = new(big.Int).Add(new(big.Int).Mul(header.BaseFee, big.NewInt(2)), tip)
+	return estimate, nil
+}
+
+// EIP-1559 parameters: blocks target half their gas limit and the base fee
+// moves by at most 1/8 (12.5%) per block
+const (
+	elasticityMultiplier     = 2
+	baseFeeChangeDenominator = 8
+)
+
+// nextBaseFee projects the base fee of the block after one with the given gas
+// usage and base fee, using the EIP-1559 update rule
+func nextBaseFee(gasUsed, gasLimit uint64, baseFee *big.Int) *big.Int {
+	target := gasLimit / elasticityMultiplier
+	if target == 0 || gasUsed == target {
+		return new(big.Int).Set(baseFee)
+	}
+
+	var diff uint64
+	if gasUsed > target {
+		diff = gasUsed - target
+	} else {
+		diff = target - gasUsed
+	}
+	delta := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(diff))
+	delta.Div(delta, new(big.Int).SetUint64(target))
+	delta.Div(delta, big.NewInt(baseFeeChangeDenominator))
+
+	if gasUsed > target {
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return delta.Add(baseFee, delta)
+	}
+	return delta.Sub(baseFee, delta)
+}
+
+// GasPriceInfo is the result of the gasprice command. Amounts are in wei.
+type GasPriceInfo struct {
+	GasPrice             string `json:"gasPrice"`
+	BaseFee              string `json:"baseFee,omitempty"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         string `json:"maxFeePerGas,omitempty"`
+}
+
+func (g GasPriceInfo) renderText(w io.Writer) {
+	unit := gasPriceUnit()
+	format := func(wei string) string {
+		return formatAmount(parseWei(wei), unit)
+	}
+
+	printField(w, "Gas Price", format(g.GasPrice))
+	if g.BaseFee != "" {
+		printField(w, "Base Fee", format(g.BaseFee))
+		printField(w, "Max Priority Fee", format(g.MaxPriorityFeePerGas))
+		printField(w, "Max Fee Per Gas", format(g.MaxFeePerGas))
+	}
+}
+
+var gasPriceCmd = &cobra.Command{
+	Use:   "gasprice",
+	Short: "Show current gas price and EIP-1559 fee suggestions",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fees, err := rpcClient.EstimateFees()
+		if err != nil {
+			fatal(err)
+		}
+
+		info := GasPriceInfo{GasPrice: fees.GasPrice.String()}
+		if fees.BaseFee != nil {
+			info.BaseFee = fees.BaseFee.String()
+			info.MaxPriorityFeePerGas = fees.TipCap.String()
+			info.MaxFeePerGas = fees.MaxFeePerGas.String()
+		}
+
+		render(info)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gasPriceCmd)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// defaultDerivationPath is the BIP-44 path of the first Ethereum account, as
+// used by most wallets
+const defaultDerivationPath = "m/44'/60'/0'/0/0"
+
+// readMnemonic returns a BIP-39 phrase given directly or as the path of a
+// file containing it, with whitespace and case normalized
+func readMnemonic(value string) string {
+	if data, err := os.ReadFile(value); err == nil {
+		value = string(data)
+	}
+	return strings.Join(strings.Fields(strings.ToLower(value)), " ")
+}
+
+// parseHDPath parses a derivation path. When account is not nil it replaces
+// the last index of the path, keeping whether that index is hardened.
+func parseHDPath(path string, account *uint32) (accounts.DerivationPath, error) {
+	parsed, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --derivation-path: %w", err)
+	}
+	if account != nil {
+		if *account >= 0x80000000 {
+			return nil, fmt.Errorf("invalid --account %d (must be below 2^31)", *account)
+		}
+		last := len(parsed) - 1
+		parsed[last] = parsed[last]&0x80000000 | *account
+	}
+	return parsed, nil
+}
+
+// deriveKey derives the private key at path from a BIP-39 mnemonic following
+// BIP-32. The mnemonic's word list and checksum are validated first; errors
+// never quote the phrase.
+func deriveKey(mnemonic string, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, fmt.Errorf("invalid mnemonic: expected 12, 15, 18, 21 or 24 words, got %d", len(words))
+	}
+	for i, word := range words {
+		if _, ok := bip39.GetWordIndex(word); !ok {
+			return nil, fmt.Errorf("invalid mnemonic: word %d is not in the BIP-39 English word list", i+1)
+		}
+	}
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: checksum mismatch (a word is probably mistyped or out of order)")
+	}
+	defer clear(seed)
+
+	key, chainCode := hmacSHA512([]byte("Bitcoin seed"), seed)
+	defer clear(key)
+
+	n := crypto.S256().Params().N
+	k := new(big.Int).SetBytes(key)
+	for _, index := range path {
+		var data []byte
+		if index >= 0x80000000 {
+			data = append([]byte{0}, key...)
+		} else {
+			priv, err := crypto.ToECDSA(key)
+			if err != nil {
+				return nil, err
+			}
+			data = crypto.CompressPubkey(&priv.PublicKey)
+			zeroKey(priv)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		tweak, childChainCode := hmacSHA512(chainCode, data)
+		clear(data)
+		il := new(big.Int).SetBytes(tweak)
+		if il.Cmp(n) >= 0 {
+			return nil, fmt.Errorf("derivation path %s yields an invalid key; use another index", path)
+		}
+		k.Add(k, il).Mod(k, n)
+		if k.Sign() == 0 {
+			return nil, fmt.Errorf("derivation path %s yields an invalid key; use another index", path)
+		}
+		clear(key)
+		key, chainCode = math.PaddedBigBytes(k, 32), childChainCode
+	}
+	clear(k.Bits())
+
+	return crypto.ToECDSA(key)
+}
+
+// hmacSHA512 returns the two 32-byte halves of HMAC-SHA512(key, data): the
+// key material and the chain code in BIP-32 terms
+func hmacSHA512(key, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
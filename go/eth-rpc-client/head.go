@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+// HeadInfo is the result of the head command
+type HeadInfo struct {
+	Number         uint64  `json:"number"`
+	Hash           string  `json:"hash"`
+	ParentHash     string  `json:"parentHash"`
+	Timestamp      uint64  `json:"timestamp"`
+	Time           string  `json:"time"`
+	GasUsed        uint64  `json:"gasUsed"`
+	GasLimit       uint64  `json:"gasLimit"`
+	GasUsedPercent float64 `json:"gasUsedPercent"`
+	BaseFee        string  `json:"baseFee,omitempty"`
+}
+
+func (h HeadInfo) renderText(w io.Writer) {
+	printField(w, "Block", h.Number)
+	printField(w, "Hash", h.Hash)
+	printField(w, "Parent Hash", h.ParentHash)
+	printField(w, "Timestamp", formatTimestamp(h.Timestamp))
+	printField(w, "Gas Used", fmt.Sprintf("%d (%.1f%%)", h.GasUsed, h.GasUsedPercent))
+	printField(w, "Gas Limit", h.GasLimit)
+	if h.BaseFee != "" {
+		printField(w, "Base Fee", formatAmount(parseWei(h.BaseFee), gasPriceUnit()))
+	}
+}
+
+// newHeadInfo builds the printable form of a header
+func newHeadInfo(header *types.Header) HeadInfo {
+	info := HeadInfo{
+		Number:     header.Number.Uint64(),
+		Hash:       header.Hash().Hex(),
+		ParentHash: header.ParentHash.Hex(),
+		Timestamp:  header.Time,
+		Time:       rfc3339UTC(header.Time),
+		GasUsed:    header.GasUsed,
+		GasLimit:   header.GasLimit,
+	}
+	if header.GasLimit > 0 {
+		info.GasUsedPercent = float64(header.GasUsed) / float64(header.GasLimit) * 100
+	}
+	if header.BaseFee != nil {
+		info.BaseFee = header.BaseFee.String()
+	}
+	return info
+}
+
+var headCmd = &cobra.Command{
+	Use:   "head [number|tag]",
+	Short: "Get the latest block header",
+	Long: `Shows the header of the latest block, or of a block given by number or tag,
+without downloading the block's transactions. Cheaper than block when only
+header fields such as the number, hash, timestamp or base fee are needed.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var number *big.Int
+		if len(args) == 1 {
+			var err error
+			if number, err = parseBlockTag(args[0]); err != nil {
+				fatal(err)
+			}
+		}
+
+		header, err := rpcClient.GetHeaderAt(number)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(newHeadInfo(header))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(headCmd)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// rpcHeaders holds the raw --header flags, e.g. "Authorization: Bearer ..."
+var rpcHeaders []string
+
+// parseHeaders parses repeated "Key: Value" header flags. Header values often
+// carry API keys or tokens, so errors only ever mention the key.
+func parseHeaders(values []string) (http.Header, error) {
+	headers := make(http.Header)
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --header (expected key:value)")
+		}
+		if strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("invalid --header name %q", key)
+		}
+		headers.Add(key, strings.TrimSpace(val))
+	}
+	return headers, nil
+}
+
+// redactHeaders returns the header names with their values hidden, for
+// printing which headers are attached to requests
+func redactHeaders(headers http.Header) []string {
+	var redacted []string
+	for key := range headers {
+		redacted = append(redacted, key+": <redacted>")
+	}
+	sort.Strings(redacted)
+	return redacted
+}
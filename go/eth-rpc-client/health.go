@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var (
+	healthMinPeers    uint64
+	healthMaxBlockLag time.Duration
+)
+
+// HealthCheck is the outcome of a single node readiness check
+type HealthCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail"`
+}
+
+// HealthReport is the result of the health command
+type HealthReport struct {
+	Healthy bool          `json:"healthy"`
+	Checks  []HealthCheck `json:"checks"`
+}
+
+// newHealthReport collects checks into a report that is healthy only when
+// every check passed
+func newHealthReport(checks []HealthCheck) HealthReport {
+	report := HealthReport{Healthy: true, Checks: checks}
+	for _, check := range checks {
+		if !check.OK {
+			report.Healthy = false
+		}
+	}
+	return report
+}
+
+func (r HealthReport) renderText(w io.Writer) {
+	for _, check := range r.Checks {
+		status := green("ok")
+		if !check.OK {
+			status = red("FAIL")
+		}
+		fmt.Fprintf(w, "%s %s %s\n", status, cyan(check.Name+":"), check.Detail)
+	}
+}
+
+// GetPeerCount returns the number of peers connected to the node via
+// net_peerCount. It returns errMethodUnsupported when the endpoint hides it.
+func (c *Client) GetPeerCount() (uint64, error) {
+	var count hexutil.Uint64
+	err := c.withRetry(func(ec *ethclient.Client) error {
+		return ec.Client().CallContext(c.ctx, &count, "net_peerCount")
+	})
+	if err != nil && isMethodNotFound(err) {
+		return 0, fmt.Errorf("net_peerCount: %w", errMethodUnsupported)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get peer count: %w", err)
+	}
+	return uint64(count), nil
+}
+
+// healthSource is the node state the readiness checks look at, implemented
+// by *Client
+type healthSource interface {
+	SyncProgress() (*ethereum.SyncProgress, error)
+	GetHeaderAt(number *big.Int) (*types.Header, error)
+	GetPeerCount() (uint64, error)
+}
+
+// CheckHealth runs the node readiness checks. The sync check always runs; the
+// block lag and peer checks run when maxBlockLag and minPeers are non-zero.
+func (c *Client) CheckHealth(minPeers uint64, maxBlockLag time.Duration) ([]HealthCheck, error) {
+	return checkHealth(c, minPeers, maxBlockLag)
+}
+
+func checkHealth(node healthSource, minPeers uint64, maxBlockLag time.Duration) ([]HealthCheck, error) {
+	var checks []HealthCheck
+
+	progress, err := node.SyncProgress()
+	if err != nil {
+		return nil, err
+	}
+	if progress == nil {
+		checks = append(checks, HealthCheck{Name: "sync", OK: true, Detail: "fully synced"})
+	} else {
+		checks = append(checks, HealthCheck{
+			Name:   "sync",
+			Detail: fmt.Sprintf("syncing (block %d of %d)", progress.CurrentBlock, progress.HighestBlock),
+		})
+	}
+
+	if maxBlockLag > 0 {
+		header, err := node.GetHeaderAt(nil)
+		if err != nil {
+			return nil, err
+		}
+		lag := time.Since(time.Unix(int64(header.Time), 0)).Truncate(time.Second)
+		checks = append(checks, HealthCheck{
+			Name:   "block lag",
+			OK:     lag <= maxBlockLag,
+			Detail: fmt.Sprintf("block %d is %s old (max %s)", header.Number, lag, maxBlockLag),
+		})
+	}
+
+	peers, err := node.GetPeerCount()
+	switch {
+	case err != nil && minPeers > 0:
+		checks = append(checks, HealthCheck{Name: "peers", Detail: err.Error()})
+	case err == nil && minPeers > 0:
+		checks = append(checks, HealthCheck{
+			Name:   "peers",
+			OK:     peers >= minPeers,
+			Detail: fmt.Sprintf("%d connected (min %d)", peers, minPeers),
+		})
+	case err == nil:
+		checks = append(checks, HealthCheck{Name: "peers", OK: true, Detail: fmt.Sprintf("%d connected", peers)})
+	}
+
+	return checks, nil
+}
+
+var healthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check node readiness",
+	Long: `Checks that the node is synced and, optionally, that its latest block is
+recent (--max-block-lag) and that it has enough peers (--min-peers).
+Exits non-zero when any check fails, so it can be used as a readiness probe.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		checks, err := rpcClient.CheckHealth(healthMinPeers, healthMaxBlockLag)
+		if err != nil {
+			fatal(err)
+		}
+
+		report := newHealthReport(checks)
+		render(report)
+		if !report.Healthy {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	healthCmd.Flags().Uint64Var(&healthMinPeers, "min-peers", 0, "Fail when the node has fewer peers (0 disables)")
+	healthCmd.Flags().DurationVar(&healthMaxBlockLag, "max-block-lag", 0, "Fail when the latest block is older than this, e.g. 60s (0 disables)")
+
+	rootCmd.AddCommand(healthCmd)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// mockNode is a healthSource answering with fixed values
+type mockNode struct {
+	progress *ethereum.SyncProgress
+	blockAge time.Duration
+	peers    uint64
+	peersErr error
+}
+
+func (m mockNode) SyncProgress() (*ethereum.SyncProgress, error) {
+	return m.progress, nil
+}
+
+func (m mockNode) GetHeaderAt(number *big.Int) (*types.Header, error) {
+	return &types.Header{
+		Number: big.NewInt(100),
+		Time:   uint64(time.Now().Add(-m.blockAge).Unix()),
+	}, nil
+}
+
+func (m mockNode) GetPeerCount() (uint64, error) {
+	return m.peers, m.peersErr
+}
+
+func TestCheckHealth(t *testing.T) {
+	tests := []struct {
+		name        string
+		node        mockNode
+		minPeers    uint64
+		maxBlockLag time.Duration
+		healthy     bool
+		failed      string
+	}{
+		{
+			name:        "healthy",
+			node:        mockNode{blockAge: 5 * time.Second, peers: 10},
+			minPeers:    3,
+			maxBlockLag: time.Minute,
+			healthy:     true,
+		},
+		{
+			name:    "syncing",
+			node:    mockNode{progress: &ethereum.SyncProgress{CurrentBlock: 50, HighestBlock: 100}, peers: 10},
+			healthy: false,
+			failed:  "sync",
+		},
+		{
+			name:        "block lag over max",
+			node:        mockNode{blockAge: 5 * time.Minute, peers: 10},
+			maxBlockLag: time.Minute,
+			healthy:     false,
+			failed:      "block lag",
+		},
+		{
+			name:     "peers below min",
+			node:     mockNode{peers: 1},
+			minPeers: 3,
+			healthy:  false,
+			failed:   "peers",
+		},
+		{
+			name:     "peer count unsupported with min",
+			node:     mockNode{peersErr: errMethodUnsupported},
+			minPeers: 3,
+			healthy:  false,
+			failed:   "peers",
+		},
+		{
+			name:    "peer count unsupported without min",
+			node:    mockNode{peersErr: errMethodUnsupported},
+			healthy: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks, err := checkHealth(tt.node, tt.minPeers, tt.maxBlockLag)
+			if err != nil {
+				t.Fatalf("checkHealth: %v", err)
+			}
+			report := newHealthReport(checks)
+			if report.Healthy != tt.healthy {
+				t.Fatalf("healthy = %v, want %v (checks %+v)", report.Healthy, tt.healthy, checks)
+			}
+			for _, check := range checks {
+				if check.OK == (check.Name == tt.failed) {
+					t.Errorf("check %q ok = %v (%s)", check.Name, check.OK, check.Detail)
+				}
+			}
+		})
+	}
+}
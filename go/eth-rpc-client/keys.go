@@ -0,0 +1,139 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Signing key flags shared by every command that signs
+var (
+	signingKey         string
+	keystorePath       string
+	keystorePassphrase string
+	mnemonic           string
+	derivationPath     string
+	hdAccount          uint32
+)
+
+// addKeyFlags registers the signing key flags on a command: --key, --keystore
+// with a --passphrase that is prompted for when omitted, or --mnemonic with
+// --derivation-path and --account
+func addKeyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&signingKey, "key", "", "Hex private key, or path to a key file or keystore JSON")
+	cmd.Flags().StringVar(&keystorePath, "keystore", "", "go-ethereum keystore JSON file holding the signing key")
+	cmd.Flags().StringVar(&keystorePassphrase, "passphrase", "", "Keystore passphrase (prompted when omitted; visible to other local users when passed as a flag)")
+	cmd.Flags().StringVar(&mnemonic, "mnemonic", "", "BIP-39 mnemonic phrase, or path to a file containing it")
+	cmd.Flags().StringVar(&derivationPath, "derivation-path", defaultDerivationPath, "BIP-32 derivation path used with --mnemonic")
+	cmd.Flags().Uint32Var(&hdAccount, "account", 0, "Account index used with --mnemonic; replaces the last index of --derivation-path")
+	cmd.MarkFlagsOneRequired("key", "keystore", "mnemonic")
+	cmd.MarkFlagsMutuallyExclusive("key", "keystore", "mnemonic")
+}
+
+// loadSigningKey loads the key selected by cmd's signing key flags. Callers
+// should zeroKey it once they are done signing; it is also zeroed on exit, for
+// commands that fail before their deferred zeroKey runs.
+func loadSigningKey(cmd *cobra.Command) (*ecdsa.PrivateKey, error) {
+	priv, err := readSigningKey(cmd)
+	if err != nil {
+		return nil, err
+	}
+	onExit(func() error {
+		zeroKey(priv)
+		return nil
+	})
+	return priv, nil
+}
+
+// readSigningKey reads the key selected by cmd's signing key flags
+func readSigningKey(cmd *cobra.Command) (*ecdsa.PrivateKey, error) {
+	hdFlagSet := cmd.Flags().Changed("derivation-path") || cmd.Flags().Changed("account")
+	if mnemonic == "" && hdFlagSet {
+		return nil, fmt.Errorf("--derivation-path and --account require --mnemonic")
+	}
+
+	if mnemonic != "" {
+		var account *uint32
+		if cmd.Flags().Changed("account") {
+			account = &hdAccount
+		}
+		path, err := parseHDPath(derivationPath, account)
+		if err != nil {
+			return nil, err
+		}
+		return deriveKey(readMnemonic(mnemonic), path)
+	}
+	if keystorePath != "" {
+		data, err := os.ReadFile(keystorePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keystore: %w", err)
+		}
+		return decryptKeystore(data)
+	}
+	return loadPrivateKey(signingKey)
+}
+
+// loadPrivateKey loads a signing key from a hex private key, a file containing
+// one, or a go-ethereum keystore JSON file
+func loadPrivateKey(key string) (*ecdsa.PrivateKey, error) {
+	if key == "" {
+		return nil, fmt.Errorf("a signing key is required (--key or --keystore)")
+	}
+
+	if data, err := os.ReadFile(key); err == nil {
+		if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+			return decryptKeystore(data)
+		}
+		key = strings.TrimSpace(string(data))
+	}
+
+	// The decoding error is not wrapped: it quotes the offending characters
+	priv, err := crypto.HexToECDSA(strings.TrimPrefix(key, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key (expected 32 bytes of hex)")
+	}
+	return priv, nil
+}
+
+// decryptKeystore decrypts a go-ethereum keystore JSON file with --passphrase,
+// prompting for the passphrase when the flag is not set
+func decryptKeystore(data []byte) (*ecdsa.PrivateKey, error) {
+	passphrase := keystorePassphrase
+	if passphrase == "" {
+		var err error
+		if passphrase, err = readPassphrase("Keystore passphrase: "); err != nil {
+			return nil, err
+		}
+	}
+
+	decrypted, err := keystore.DecryptKey(data, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+	return decrypted.PrivateKey, nil
+}
+
+// zeroKey overwrites a private key's secret scalar so it does not linger in
+// memory after use
+func zeroKey(k *ecdsa.PrivateKey) {
+	if k != nil && k.D != nil {
+		clear(k.D.Bits())
+	}
+}
+
+// readPassphrase prompts on stderr and reads a passphrase from the terminal without echo
+func readPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	return string(passphrase), nil
+}
@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsAddresses []string
+	logsTopics    []string
+	logsFromBlock string
+	logsToBlock   string
+	logsFollow    bool
+	logsExport    string
+)
+
+// logsChunkSize is the number of blocks each eth_getLogs request covers when
+// a range is exported with --export
+const logsChunkSize = 2000
+
+// SubscribeLogs streams logs matching the query into ch (websocket or IPC endpoint required)
+func (c *Client) SubscribeLogs(query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	if err := c.throttle(); err != nil {
+		return nil, err
+	}
+	sub, err := c.SubscribeFilterLogs(c.ctx, query, ch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to logs: %w", err)
+	}
+	return sub, nil
+}
+
+// followLogs calls fn for each log the subscription delivers on ch until the
+// context is cancelled or the subscription fails, then unsubscribes
+func followLogs(ctx context.Context, sub ethereum.Subscription, ch <-chan types.Log, fn func(types.Log) error) error {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return fmt.Errorf("subscription failed: %w", err)
+		case l := <-ch:
+			if err := fn(l); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// GetLogs returns the logs matching a bounded query
+func (c *Client) GetLogs(query ethereum.FilterQuery) ([]types.Log, error) {
+	var logs []types.Log
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		logs, err = ec.FilterLogs(c.ctx, query)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter logs: %w", err)
+	}
+	return logs, nil
+}
+
+// GetLogsChunked queries the logs matching query between from and to
+// (inclusive) in windows of chunk blocks and calls fn for each log in order,
+// so a long range is never held in memory at once
+func (c *Client) GetLogsChunked(query ethereum.FilterQuery, from, to, chunk uint64, fn func(types.Log) error) error {
+	for start := from; start <= to; start += chunk {
+		end := min(start+chunk-1, to)
+		query.FromBlock = new(big.Int).SetUint64(start)
+		query.ToBlock = new(big.Int).SetUint64(end)
+
+		logs, err := c.GetLogs(query)
+		if err != nil {
+			return fmt.Errorf("blocks %d-%d: %w", start, end, err)
+		}
+		for _, l := range logs {
+			if err := fn(l); err != nil {
+				return err
+			}
+		}
+		if end == to {
+			break
+		}
+	}
+	return nil
+}
+
+// parseTopics converts --topic values into filter topic positions. Each value
+// is one position; alternatives are comma-separated and "" or "*" matches anything.
+func parseTopics(values []string) ([][]common.Hash, error) {
+	topics := make([][]common.Hash, len(values))
+	for i, value := range values {
+		if value == "" || value == "*" {
+			continue
+		}
+		for _, alt := range strings.Split(value, ",") {
+			b, err := hexutil.Decode(alt)
+			if err != nil || len(b) != common.HashLength {
+				return nil, fmt.Errorf("invalid topic %q: expected a 32-byte hex value", alt)
+			}
+			topics[i] = append(topics[i], common.BytesToHash(b))
+		}
+	}
+	return topics, nil
+}
+
+// LogEvent is the printable form of a log entry
+type LogEvent struct {
+	Address     string   `json:"address"`
+	BlockNumber uint64   `json:"blockNumber"`
+	TxHash      string   `json:"transactionHash"`
+	LogIndex    uint     `json:"logIndex"`
+	Topics      []string `json:"topics"`
+	Data        string   `json:"data"`
+	Removed     bool     `json:"removed,omitempty"`
+
+	// Decoded is set when the log matched an event in a user-supplied ABI
+	Decoded *DecodedEvent `json:"decoded,omitempty"`
+}
+
+// newLogEvent builds the printable form of a log entry
+func newLogEvent(l types.Log) LogEvent {
+	event := LogEvent{
+		Address:     l.Address.Hex(),
+		BlockNumber: l.BlockNumber,
+		TxHash:      l.TxHash.Hex(),
+		LogIndex:    l.Index,
+		Topics:      make([]string, len(l.Topics)),
+		Data:        hexutil.Encode(l.Data),
+		Removed:     l.Removed,
+	}
+	for i, topic := range l.Topics {
+		event.Topics[i] = topic.Hex()
+	}
+	return event
+}
+
+func (e LogEvent) renderText(w io.Writer) {
+	header := fmt.Sprintf("Block #%d tx %s log %d", e.BlockNumber, e.TxHash, e.LogIndex)
+	if e.Removed {
+		header += " (removed by reorg)"
+	}
+	fmt.Fprintf(w, "%s\n", cyan(header))
+	printField(w, "  Address", e.Address)
+	if e.Decoded != nil {
+		printField(w, "  Event", e.Decoded.Event)
+		for _, arg := range e.Decoded.Args {
+			label := arg.Name
+			if label == "" {
+				label = arg.Type
+			}
+			printField(w, "    "+label, arg.Value)
+		}
+		return
+	}
+	for i, topic := range e.Topics {
+		printField(w, fmt.Sprintf("  Topic %d", i), topic)
+	}
+	printField(w, "  Data", e.Data)
+}
+
+// logCSVHeader names the columns of a logs --export CSV file. Logs have up to
+// four topics; args holds the decoded arguments as a JSON object.
+var logCSVHeader = []string{"block_number", "transaction_hash", "log_index", "address",
+	"topic0", "topic1", "topic2", "topic3", "data", "removed", "event", "args"}
+
+func (e LogEvent) csvRecord() []string {
+	record := []string{strconv.FormatUint(e.BlockNumber, 10), e.TxHash, strconv.FormatUint(uint64(e.LogIndex), 10), e.Address}
+	for i := 0; i < 4; i++ {
+		topic := ""
+		if i < len(e.Topics) {
+			topic = e.Topics[i]
+		}
+		record = append(record, topic)
+	}
+	record = append(record, e.Data, strconv.FormatBool(e.Removed))
+
+	event, args := "", ""
+	if e.Decoded != nil {
+		event = e.Decoded.Event
+		fields := make(map[string]string, len(e.Decoded.Args))
+		for i, arg := range e.Decoded.Args {
+			name := arg.Name
+			if name == "" {
+				name = fmt.Sprintf("arg%d", i)
+			}
+			fields[name] = arg.Value
+		}
+		data, _ := json.Marshal(fields)
+		args = string(data)
+	}
+	return append(record, event, args)
+}
+
+// LogEvents is the result of a bounded logs query
+type LogEvents []LogEvent
+
+func (l LogEvents) renderText(w io.Writer) {
+	for _, e := range l {
+		e.renderText(w)
+	}
+	printField(w, "Matched", len(l))
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Query or follow contract event logs",
+	Long: `Queries logs matching --address and --topic filters over a block range, or
+with --follow streams new matching logs as they are mined (websocket or IPC
+endpoint required). Repeat --topic for each topic position; separate alternatives with
+commas and use "*" as a wildcard. With --abi, logs whose first topic matches an
+event in the ABI are decoded into named arguments.
+
+--export writes the logs to a .json or .csv file instead of printing them. The
+file is written as logs arrive, and a bounded range is queried in chunks of
+2000 blocks, so exports of long ranges do not build up in memory.`,
+	Annotations: map[string]string{annotationLongRunning: "follow", annotationStreaming: "follow"},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		topics, err := parseTopics(logsTopics)
+		if err != nil {
+			fatal(err)
+		}
+
+		query := ethereum.FilterQuery{Topics: topics}
+		for _, address := range logsAddresses {
+			if !common.IsHexAddress(address) {
+				fatalf("invalid address %q", address)
+			}
+			query.Addresses = append(query.Addresses, common.HexToAddress(address))
+		}
+		if logsFromBlock != "" {
+			if query.FromBlock, err = parseBlockTag(logsFromBlock); err != nil {
+				fatal(err)
+			}
+		}
+		if logsToBlock != "" {
+			if query.ToBlock, err = parseBlockTag(logsToBlock); err != nil {
+				fatal(err)
+			}
+		}
+
+		if logsFollow {
+			if err := requireSubscriptions(rpcURLs); err != nil {
+				fatal(err)
+			}
+		}
+
+		var decode func(l *types.Log) *DecodedEvent
+		if abiRequested() {
+			if decode, err = rpcClient.logDecoder(); err != nil {
+				fatal(err)
+			}
+		}
+		toEvent := func(l types.Log) LogEvent {
+			event := newLogEvent(l)
+			if decode != nil {
+				event.Decoded = decode(&l)
+			}
+			return event
+		}
+
+		var out *fileExporter
+		if logsExport != "" {
+			if out, err = createExporter(logsExport, logCSVHeader); err != nil {
+				fatal(err)
+			}
+			defer func() {
+				if err := out.close(); err != nil {
+					fatal(err)
+				}
+				fmt.Fprintf(progressWriter(), "Wrote %d logs to %s\n", out.count, logsExport)
+			}()
+		}
+
+		if !logsFollow && out != nil {
+			// Like eth_getLogs, an omitted end of the range means latest
+			fromBlock, toBlock := logsFromBlock, logsToBlock
+			if fromBlock == "" {
+				fromBlock = "latest"
+			}
+			if toBlock == "" {
+				toBlock = "latest"
+			}
+			from, err := rpcClient.resolveBlockNumber(fromBlock)
+			if err != nil {
+				fatal(err)
+			}
+			to, err := rpcClient.resolveBlockNumber(toBlock)
+			if err != nil {
+				fatal(err)
+			}
+			if from > to {
+				fatalf("--from-block %d is after --to-block %d", from, to)
+			}
+
+			err = rpcClient.GetLogsChunked(query, from, to, logsChunkSize, func(l types.Log) error {
+				return out.write(toEvent(l))
+			})
+			if err != nil {
+				fatal(err)
+			}
+			return
+		}
+
+		if !logsFollow {
+			logs, err := rpcClient.GetLogs(query)
+			if err != nil {
+				fatal(err)
+			}
+
+			events := make(LogEvents, len(logs))
+			for i, l := range logs {
+				events[i] = toEvent(l)
+			}
+			render(events)
+			return
+		}
+
+		ch := make(chan types.Log)
+		sub, err := rpcClient.SubscribeLogs(query, ch)
+		if err != nil {
+			fatal(err)
+		}
+		err = followLogs(rpcClient.ctx, sub, ch, func(l types.Log) error {
+			if out == nil {
+				renderEvent(toEvent(l))
+				return nil
+			}
+			return out.write(toEvent(l))
+		})
+		if err != nil {
+			fatal(err)
+		}
+	},
+}
+
+func init() {
+	logsCmd.Flags().StringArrayVar(&logsAddresses, "address", nil, "Contract address to match (repeatable)")
+	logsCmd.Flags().StringArrayVar(&logsTopics, "topic", nil, "Topic to match at the next position (repeatable)")
+	logsCmd.Flags().StringVar(&logsFromBlock, "from-block", "", "First block of the range")
+	logsCmd.Flags().StringVar(&logsToBlock, "to-block", "", "Last block of the range (default latest)")
+	logsCmd.Flags().BoolVar(&logsFollow, "follow", false, "Stream new matching logs (websocket or IPC endpoint required)")
+	addABIFlags(logsCmd, "Decode logs with the events in this JSON ABI, as a file or inline JSON")
+	logsCmd.Flags().StringVar(&logsExport, "export", "", "Write the logs to a .json or .csv file instead of printing them")
+
+	rootCmd.AddCommand(logsCmd)
+}
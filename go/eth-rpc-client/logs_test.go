@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// mockSubscription is an ethereum.Subscription whose failure the test controls
+type mockSubscription struct {
+	errc         chan error
+	unsubscribed bool
+}
+
+func (s *mockSubscription) Err() <-chan error { return s.errc }
+
+func (s *mockSubscription) Unsubscribe() { s.unsubscribed = true }
+
+// captureOutput renders results into a buffer in the given format for the
+// rest of the test
+func captureOutput(t *testing.T, format string) *bytes.Buffer {
+	var buf bytes.Buffer
+	prevFormat, prevOut := outputFormat, resultOut
+	outputFormat, resultOut = format, &buf
+	t.Cleanup(func() { outputFormat, resultOut = prevFormat, prevOut })
+	return &buf
+}
+
+func TestFollowLogsNDJSON(t *testing.T) {
+	buf := captureOutput(t, OutputNDJSON)
+	logs := []types.Log{
+		{Address: common.HexToAddress("0xaa"), BlockNumber: 100, Index: 0, Topics: []common.Hash{common.HexToHash("0x01")}},
+		{Address: common.HexToAddress("0xbb"), BlockNumber: 101, Index: 3, Removed: true},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := &mockSubscription{errc: make(chan error)}
+	ch := make(chan types.Log)
+	rendered := make(chan struct{})
+	go func() {
+		defer cancel()
+		for i, l := range logs {
+			ch <- l
+			<-rendered
+			// Each event is written out in full before the next one arrives
+			if n := strings.Count(buf.String(), "\n"); n != i+1 {
+				t.Errorf("after event %d: %d lines written, want %d", i, n, i+1)
+			}
+		}
+	}()
+
+	err := followLogs(ctx, sub, ch, func(l types.Log) error {
+		renderEvent(newLogEvent(l))
+		rendered <- struct{}{}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("followLogs: %v", err)
+	}
+	if !sub.unsubscribed {
+		t.Error("subscription was not unsubscribed")
+	}
+
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(got) != len(logs) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(got), len(logs), buf)
+	}
+	for i, line := range got {
+		var event LogEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("line %d is not JSON: %v\n%s", i, err, line)
+		}
+		if event.BlockNumber != logs[i].BlockNumber || event.Removed != logs[i].Removed {
+			t.Errorf("line %d = %+v, want block %d removed %v", i, event, logs[i].BlockNumber, logs[i].Removed)
+		}
+	}
+}
+
+func TestFollowLogsSubscriptionError(t *testing.T) {
+	captureOutput(t, OutputNDJSON)
+	sub := &mockSubscription{errc: make(chan error, 1)}
+	sub.errc <- errors.New("connection reset")
+
+	err := followLogs(context.Background(), sub, make(chan types.Log), func(types.Log) error {
+		t.Error("no log was sent")
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Fatalf("err = %v, want the subscription error", err)
+	}
+	if !sub.unsubscribed {
+		t.Error("subscription was not unsubscribed")
+	}
+}
+
+func TestValidateOutputNDJSON(t *testing.T) {
+	captureOutput(t, OutputNDJSON)
+	t.Cleanup(func() { logsCmd.Flags().Set("follow", "false") })
+
+	if err := validateOutput(watchCmd); err != nil {
+		t.Errorf("watch: %v", err)
+	}
+	if err := validateOutput(balanceCmd); err == nil {
+		t.Error("balance accepted --output ndjson")
+	}
+	if err := validateOutput(logsCmd); err == nil {
+		t.Error("logs without --follow accepted --output ndjson")
+	}
+	logsCmd.Flags().Set("follow", "true")
+	if err := validateOutput(logsCmd); err != nil {
+		t.Errorf("logs --follow: %v", err)
+	}
+}
@@ -3,42 +3,245 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"io"
 	"math/big"
 	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/fatih/color"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
 )
 
-var rpcURL string
+var (
+	// rpcClient is the client shared by all commands, created once before the
+	// command runs and closed after it finishes
+	rpcClient *Client
 
-// Client wraps ethclient for convenience
+	rpcURLs      []string
+	rpcTimeout   time.Duration
+	balanceBlock string
+)
+
+// Client wraps ethclient for convenience. The embedded client is the first
+// endpoint that could be dialed; RPC calls made through withRetry fail over
+// to the remaining endpoints in order.
 type Client struct {
 	*ethclient.Client
-	ctx context.Context
+	endpoints []*ethclient.Client
+	breakers  []*circuitBreaker
+	ctx       context.Context
+	cancel    context.CancelFunc
+
+	retries    int
+	retryDelay time.Duration
+	limiter    *rate.Limiter
+
+	tokensMu sync.Mutex
+	tokens   map[common.Address]*TokenMeta
+
+	// cache is the --cache-dir response cache, nil when disabled
+	cache          *diskCache
+	cacheMu        sync.Mutex
+	cacheChainID   *big.Int
+	cacheFinalized *big.Int
 }
 
-// NewClient creates a new Ethereum client
-func NewClient(url string) (*Client, error) {
-	client, err := ethclient.Dial(url)
+// NewClient creates a new Ethereum client for one or more endpoints, given in
+// order of preference: http(s):// and ws(s):// URLs, or IPC socket paths.
+// Endpoints that cannot be dialed are skipped, and the --header flags are
+// sent with every HTTP and websocket request. Every call
+// made through the client shares one context that expires after --timeout
+// (when non-zero) and is cancelled on Ctrl-C or SIGTERM, so a hung node
+// cannot block a command forever.
+func NewClient(urls ...string) (*Client, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no RPC endpoint given")
+	}
+
+	headers, err := parseHeaders(rpcHeaders)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect: %w", err)
+		return nil, err
+	}
+	limiter, err := newLimiter(rpcRate)
+	if err != nil {
+		return nil, err
+	}
+	cache, err := newDiskCache()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := commandContext()
+
+	var (
+		endpoints []*ethclient.Client
+		breakers  []*circuitBreaker
+		dialErr   error
+	)
+	for _, url := range urls {
+		breaker, err := newCircuitBreaker(url)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		rc, err := rpc.DialOptions(ctx, dialTarget(url), rpc.WithHeaders(headers))
+		if err != nil {
+			dialErr = err
+			continue
+		}
+		endpoints = append(endpoints, ethclient.NewClient(rc))
+		breakers = append(breakers, breaker)
+	}
+	if len(endpoints) == 0 {
+		cancel()
+		return nil, fmt.Errorf("failed to connect: %w", dialErr)
 	}
 
-	return &Client{
-		Client: client,
-		ctx:    context.Background(),
-	}, nil
+	client := &Client{
+		Client:     endpoints[0],
+		endpoints:  endpoints,
+		breakers:   breakers,
+		ctx:        ctx,
+		cancel:     cancel,
+		retries:    rpcRetries,
+		retryDelay: rpcRetryDelay,
+		limiter:    limiter,
+		cache:      cache,
+		tokens:     make(map[common.Address]*TokenMeta),
+	}
+
+	if expectedChainID != 0 {
+		chainID, err := client.GetChainID()
+		if err != nil {
+			client.Close()
+			return nil, err
+		}
+		if chainID.Uint64() != expectedChainID {
+			client.Close()
+			return nil, fmt.Errorf("network %s expects chain ID %d but the endpoint reports %s", networkName, expectedChainID, describeChain(chainID))
+		}
+	}
+	return client, nil
+}
+
+// commandContext returns a context cancelled by SIGINT or SIGTERM and bounded
+// by --timeout. After the first signal the default handler is restored, so a
+// second Ctrl-C terminates immediately.
+func commandContext() (context.Context, context.CancelFunc) {
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigCtx.Done()
+		stop()
+	}()
+
+	if rpcTimeout <= 0 {
+		return sigCtx, stop
+	}
+	ctx, cancel := context.WithTimeout(sigCtx, rpcTimeout)
+	return ctx, func() {
+		cancel()
+		stop()
+	}
 }
 
-// GetBalance returns the ETH balance for an address
+// Command annotations read by rootCmd before the shared client is created
+const (
+	// annotationOffline marks commands that never talk to a node
+	annotationOffline = "offline"
+
+	// annotationOwnConnection marks commands that dial the endpoints
+	// themselves, e.g. to report on each one, rather than through the
+	// shared client
+	annotationOwnConnection = "own-connection"
+
+	// annotationLongRunning marks commands that run until interrupted. An
+	// empty value means always; otherwise it names the bool flag that makes
+	// the command long-running, e.g. "follow".
+	annotationLongRunning = "long-running"
+)
+
+// needsClient reports whether a command talks to a node through the shared
+// client. Cobra's built-in help and completion commands never do.
+func needsClient(cmd *cobra.Command) bool {
+	if _, offline := cmd.Annotations[annotationOffline]; offline {
+		return false
+	}
+	if _, own := cmd.Annotations[annotationOwnConnection]; own {
+		return false
+	}
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c.Name() {
+		case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return false
+		}
+	}
+	return true
+}
+
+// isLongRunning reports whether a command, with its current flags, runs until
+// interrupted and so should not be cut off by the default --timeout
+func isLongRunning(cmd *cobra.Command) bool {
+	return annotationEnabled(cmd, annotationLongRunning)
+}
+
+// annotationEnabled reports whether an annotation whose value is empty or
+// names a bool flag, such as annotationLongRunning, applies to the command
+// with its current flags
+func annotationEnabled(cmd *cobra.Command, annotation string) bool {
+	flag, ok := cmd.Annotations[annotation]
+	if !ok {
+		return false
+	}
+	if flag == "" {
+		return true
+	}
+	enabled, _ := cmd.Flags().GetBool(flag)
+	return enabled
+}
+
+// Close cancels the client's context and closes every endpoint connection
+func (c *Client) Close() {
+	c.cancel()
+	for _, endpoint := range c.endpoints {
+		endpoint.Close()
+	}
+}
+
+// sleep pauses for d, returning early when the client's context is done
+func (c *Client) sleep(d time.Duration) {
+	select {
+	case <-c.ctx.Done():
+	case <-time.After(d):
+	}
+}
+
+// GetBalance returns the ETH balance for an address or ENS name
 func (c *Client) GetBalance(address string) (*big.Int, error) {
-	addr := common.HexToAddress(address)
-	balance, err := c.BalanceAt(c.ctx, addr, nil)
+	return c.GetBalanceAt(address, nil)
+}
+
+// GetBalanceAt returns the ETH balance for an address or ENS name at the
+// given block number or tag (see parseBlockTag), or the latest block when nil
+func (c *Client) GetBalanceAt(address string, block *big.Int) (*big.Int, error) {
+	addr, err := c.Resolve(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance *big.Int
+	err = c.withRetry(func(ec *ethclient.Client) (err error) {
+		balance, err = ec.BalanceAt(c.ctx, addr, block)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
 	}
@@ -47,7 +250,11 @@ func (c *Client) GetBalance(address string) (*big.Int, error) {
 
 // GetBlockNumber returns the latest block number
 func (c *Client) GetBlockNumber() (uint64, error) {
-	number, err := c.BlockNumber(c.ctx)
+	var number uint64
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		number, err = ec.BlockNumber(c.ctx)
+		return err
+	})
 	if err != nil {
 		return 0, fmt.Errorf("failed to get block number: %w", err)
 	}
@@ -56,16 +263,72 @@ func (c *Client) GetBlockNumber() (uint64, error) {
 
 // GetBlock returns block details
 func (c *Client) GetBlock(number uint64) (*types.Block, error) {
-	block, err := c.BlockByNumber(c.ctx, big.NewInt(int64(number)))
+	return c.GetBlockAt(new(big.Int).SetUint64(number))
+}
+
+// GetBlockAt returns block details for a block number or tag (see
+// parseBlockTag). Finalized blocks requested by number are cached when
+// --cache-dir is set.
+func (c *Client) GetBlockAt(number *big.Int) (*types.Block, error) {
+	fetch := func() (*types.Block, error) {
+		var block *types.Block
+		err := c.withRetry(func(ec *ethclient.Client) (err error) {
+			block, err = ec.BlockByNumber(c.ctx, number)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block: %w", err)
+		}
+		return block, nil
+	}
+
+	if c.cache != nil && number != nil && number.Sign() >= 0 {
+		return c.cachedBlock("eth_getBlockByNumber", number.String(), fetch)
+	}
+	return fetch()
+}
+
+// GetBlockByHash returns block details for a block hash
+func (c *Client) GetBlockByHash(hash string) (*types.Block, error) {
+	blockHash, err := parseHash(hash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get block: %w", err)
+		return nil, err
+	}
+
+	fetch := func() (*types.Block, error) {
+		var block *types.Block
+		err := c.withRetry(func(ec *ethclient.Client) (err error) {
+			block, err = ec.BlockByHash(c.ctx, blockHash)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block: %w", err)
+		}
+		return block, nil
+	}
+
+	if c.cache != nil {
+		return c.cachedBlock("eth_getBlockByHash", strings.ToLower(blockHash.Hex()), fetch)
 	}
-	return block, nil
+	return fetch()
+}
+
+// parseHash parses a 0x-prefixed 32-byte hex hash
+func parseHash(s string) (common.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid hash %q (expected 0x followed by 64 hex characters)", s)
+	}
+	return common.BytesToHash(b), nil
 }
 
 // GetChainID returns the chain ID
 func (c *Client) GetChainID() (*big.Int, error) {
-	chainID, err := c.ChainID(c.ctx)
+	var chainID *big.Int
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		chainID, err = ec.ChainID(c.ctx)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
@@ -76,106 +339,351 @@ var rootCmd = &cobra.Command{
 	Use:   "eth-rpc",
 	Short: "Ethereum RPC client CLI",
 	Long:  `A command-line interface for interacting with Ethereum nodes via JSON-RPC`,
+	// main prints the error, so it is not printed twice
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutput(cmd); err != nil {
+			return err
+		}
+		if err := validateUnit(); err != nil {
+			return err
+		}
+		if err := applyConfig(cmd); err != nil {
+			return err
+		}
+		if err := openOutput(); err != nil {
+			return err
+		}
+		onExit(closeOutput)
+
+		// The flags are valid, so failures from here on are not usage errors
+		cmd.SilenceUsage = true
+		if !needsClient(cmd) {
+			return nil
+		}
+
+		if preflightCheck {
+			if err := preflight(); err != nil {
+				return err
+			}
+		}
+
+		// Long-running commands only honour an explicit --timeout
+		if isLongRunning(cmd) && !cmd.Flags().Changed("timeout") {
+			rpcTimeout = 0
+		}
+
+		client, err := NewClient(rpcURLs...)
+		if err != nil {
+			return err
+		}
+		rpcClient = client
+		onExit(func() error {
+			client.Close()
+			return nil
+		})
+		return nil
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if err := runExitHooks(); err != nil {
+			fatal(err)
+		}
+	},
+}
+
+// ChainInfo is the result of the info command
+type ChainInfo struct {
+	ChainID     string   `json:"chainId"`
+	ChainName   string   `json:"chainName,omitempty"`
+	LatestBlock uint64   `json:"latestBlock"`
+	RPCURL      string   `json:"rpcUrl"`
+	Headers     []string `json:"headers,omitempty"`
+}
+
+func (i ChainInfo) renderText(w io.Writer) {
+	name := i.ChainName
+	if name == "" {
+		name = "Unknown"
+	}
+	printField(w, "Chain", fmt.Sprintf("%s (%s)", name, i.ChainID))
+	printField(w, "Latest Block", i.LatestBlock)
+	printField(w, "RPC URL", i.RPCURL)
+	if len(i.Headers) > 0 {
+		printField(w, "Headers", strings.Join(i.Headers, ", "))
+	}
 }
 
 var infoCmd = &cobra.Command{
 	Use:   "info",
 	Short: "Display blockchain information",
 	Run: func(cmd *cobra.Command, args []string) {
-		client, err := NewClient(rpcURL)
+		chainID, err := rpcClient.GetChainID()
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
-		defer client.Close()
 
-		chainID, err := client.GetChainID()
+		blockNum, err := rpcClient.GetBlockNumber()
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
-		blockNum, err := client.GetBlockNumber()
+		headers, err := parseHeaders(rpcHeaders)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
-		green := color.New(color.FgGreen).SprintFunc()
-		cyan := color.New(color.FgCyan).SprintFunc()
-
-		fmt.Printf("%s %s\n", cyan("Chain ID:"), green(chainID.String()))
-		fmt.Printf("%s %s\n", cyan("Latest Block:"), green(blockNum))
-		fmt.Printf("%s %s\n", cyan("RPC URL:"), green(rpcURL))
+		render(ChainInfo{
+			ChainID:     chainID.String(),
+			ChainName:   chainName(chainID),
+			LatestBlock: blockNum,
+			RPCURL:      strings.Join(rpcURLs, ", "),
+			Headers:     redactHeaders(headers),
+		})
 	},
 }
 
+// BalanceInfo is the result of the balance command
+type BalanceInfo struct {
+	Name    string     `json:"name,omitempty"`
+	Address string     `json:"address"`
+	Wei     string     `json:"wei"`
+	Ether   string     `json:"ether"`
+	Fiat    *FiatValue `json:"fiat,omitempty"`
+
+	// Explorer is the block explorer link added with --explorer
+	Explorer string `json:"explorer,omitempty"`
+}
+
+func (b BalanceInfo) renderText(w io.Writer) {
+	fmt.Fprintf(w, "Balance: %s\n", green(formatAmount(parseWei(b.Wei), amountUnit)))
+	if b.Fiat != nil {
+		fmt.Fprintf(w, "Value: %s\n", green(b.Fiat))
+	}
+	if b.Explorer != "" {
+		fmt.Fprintf(w, "Explorer: %s\n", b.Explorer)
+	}
+}
+
 var balanceCmd = &cobra.Command{
-	Use:   "balance [address]",
+	Use:   "balance [address|ens-name]",
 	Short: "Get ETH balance for address",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		client, err := NewClient(rpcURL)
+		var block *big.Int
+		if balanceBlock != "" {
+			var err error
+			if block, err = parseBlockTag(balanceBlock); err != nil {
+				fatal(err)
+			}
+		}
+
+		addr, err := rpcClient.Resolve(args[0])
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
-		defer client.Close()
 
-		balance, err := client.GetBalance(args[0])
+		balance, err := rpcClient.GetBalanceAt(addr.Hex(), block)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 
-		ethBalance := new(big.Float).Quo(
-			new(big.Float).SetInt(balance),
-			big.NewFloat(1e18),
-		)
+		info := BalanceInfo{
+			Address: addr.Hex(),
+			Wei:     balance.String(),
+			Ether:   formatEther(balance),
+		}
+		if !common.IsHexAddress(args[0]) {
+			info.Name = args[0]
+		}
 
-		green := color.New(color.FgGreen).SprintFunc()
-		fmt.Printf("Balance: %s ETH\n", green(ethBalance.Text('f', 6)))
+		if showExplorer {
+			chainID, err := rpcClient.GetChainID()
+			if err != nil {
+				fatal(err)
+			}
+			if info.Explorer, err = explorerLink(chainID, "/address/"+info.Address); err != nil {
+				fatal(err)
+			}
+		}
+
+		var priceErr error
+		if fiatCurrency != "" {
+			url, path := priceSource(nil, defaultETHPriceURL, defaultETHPricePath)
+			var price *big.Float
+			if price, priceErr = fetchPrice(rpcClient.ctx, url, path); priceErr == nil {
+				info.Fiat = newFiatValue(formatUnits(balance, 18), price)
+			}
+		}
+
+		render(info)
+		if priceErr != nil {
+			printPriceNote(priceErr)
+		}
 	},
 }
 
+// BlockInfo is the result of the block command
+type BlockInfo struct {
+	Number         uint64  `json:"number"`
+	Hash           string  `json:"hash"`
+	ParentHash     string  `json:"parentHash"`
+	Timestamp      uint64  `json:"timestamp"`
+	Time           string  `json:"time"`
+	Transactions   int     `json:"transactions"`
+	GasUsed        uint64  `json:"gasUsed"`
+	GasLimit       uint64  `json:"gasLimit"`
+	GasUsedPercent float64 `json:"gasUsedPercent"`
+	BaseFee        string  `json:"baseFee,omitempty"`
+	NextBaseFee    string  `json:"nextBaseFee,omitempty"`
+
+	// TopTransactions is set by block --top
+	TopTransactions []TopTransaction `json:"topTransactions,omitempty"`
+	SkippedSenders  int              `json:"skippedSenders,omitempty"`
+
+	// Explorer is the block explorer link added with --explorer
+	Explorer string `json:"explorer,omitempty"`
+}
+
+func (b BlockInfo) renderText(w io.Writer) {
+	fmt.Fprintf(w, "\n%s\n\n", cyan(fmt.Sprintf("Block #%d", b.Number)))
+	printField(w, "Hash", b.Hash)
+	printField(w, "Parent Hash", b.ParentHash)
+	printField(w, "Timestamp", formatTimestamp(b.Timestamp))
+	printField(w, "Transactions", b.Transactions)
+	printField(w, "Gas Used", fmt.Sprintf("%d (%.1f%%)", b.GasUsed, b.GasUsedPercent))
+	printField(w, "Gas Limit", b.GasLimit)
+	if b.BaseFee != "" {
+		printField(w, "Base Fee", formatAmount(parseWei(b.BaseFee), gasPriceUnit()))
+		printField(w, "Next Base Fee", formatAmount(parseWei(b.NextBaseFee), gasPriceUnit()))
+	}
+	if b.Explorer != "" {
+		printField(w, "Explorer", b.Explorer)
+	}
+	if b.TopTransactions != nil || b.SkippedSenders > 0 {
+		renderTopTransactions(w, b.TopTransactions, b.SkippedSenders)
+	}
+}
+
+// newBlockInfo builds the printable form of a block. Post-London blocks also
+// show their base fee and the projected base fee of the next block.
+func newBlockInfo(block *types.Block) BlockInfo {
+	info := BlockInfo{
+		Number:       block.NumberU64(),
+		Hash:         block.Hash().Hex(),
+		ParentHash:   block.ParentHash().Hex(),
+		Timestamp:    block.Time(),
+		Time:         rfc3339UTC(block.Time()),
+		Transactions: len(block.Transactions()),
+		GasUsed:      block.GasUsed(),
+		GasLimit:     block.GasLimit(),
+	}
+	if block.GasLimit() > 0 {
+		info.GasUsedPercent = float64(block.GasUsed()) / float64(block.GasLimit()) * 100
+	}
+	if baseFee := block.BaseFee(); baseFee != nil {
+		info.BaseFee = baseFee.String()
+		info.NextBaseFee = nextBaseFee(block.GasUsed(), block.GasLimit(), baseFee).String()
+	}
+	return info
+}
+
 var blockCmd = &cobra.Command{
-	Use:   "block [number]",
+	Use:   "block [number|tag]",
 	Short: "Get block information",
-	Args:  cobra.ExactArgs(1),
+	Long: `Shows a block by number (decimal or 0x hex) or by tag: latest, pending,
+safe, finalized or earliest. With --top N the block's N highest-value
+transactions are listed with their sender, recipient and value.`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		client, err := NewClient(rpcURL)
+		blockNum, err := parseBlockTag(args[0])
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
-		defer client.Close()
-
-		blockNum := new(big.Int)
-		blockNum.SetString(args[0], 10)
 
-		block, err := client.GetBlock(blockNum.Uint64())
+		block, err := rpcClient.GetBlockAt(blockNum)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
+		}
+
+		info := newBlockInfo(block)
+		if blockTop > 0 || showExplorer {
+			chainID, err := rpcClient.GetChainID()
+			if err != nil {
+				fatal(err)
+			}
+			if blockTop > 0 {
+				info.TopTransactions, info.SkippedSenders = topTransactions(block, chainID, blockTop)
+			}
+			if showExplorer {
+				if info.Explorer, err = explorerLink(chainID, fmt.Sprintf("/block/%d", info.Number)); err != nil {
+					fatal(err)
+				}
+			}
+		}
+
+		render(info)
+	},
+}
+
+var blockHashCmd = &cobra.Command{
+	Use:   "block-hash [hash]",
+	Short: "Get block information by block hash",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := parseHash(args[0]); err != nil {
+			fatal(err)
 		}
 
-		cyan := color.New(color.FgCyan).SprintFunc()
-		green := color.New(color.FgGreen).SprintFunc()
+		block, err := rpcClient.GetBlockByHash(args[0])
+		if err != nil {
+			fatal(err)
+		}
 
-		fmt.Printf("\n%s\n\n", cyan(fmt.Sprintf("Block #%d", block.NumberU64())))
-		fmt.Printf("%s %s\n", cyan("Hash:"), green(block.Hash().Hex()))
-		fmt.Printf("%s %s\n", cyan("Parent Hash:"), green(block.ParentHash().Hex()))
-		fmt.Printf("%s %s\n", cyan("Timestamp:"), green(block.Time()))
-		fmt.Printf("%s %s\n", cyan("Transactions:"), green(len(block.Transactions())))
-		fmt.Printf("%s %s\n", cyan("Gas Used:"), green(block.GasUsed()))
-		fmt.Printf("%s %s\n", cyan("Gas Limit:"), green(block.GasLimit()))
+		render(newBlockInfo(block))
 	},
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&rpcURL, "rpc", "r", "http://localhost:8545", "Ethereum RPC URL")
+	rootCmd.PersistentFlags().StringSliceVarP(&rpcURLs, "rpc", "r", []string{"http://localhost:8545"}, "Ethereum RPC URL or IPC socket path; repeat or comma-separate for fallback endpoints, tried in order")
+	rootCmd.PersistentFlags().StringArrayVarP(&rpcHeaders, "header", "H", nil, "HTTP header sent with every RPC request, e.g. \"Authorization: Bearer TOKEN\" (repeatable)")
+	rootCmd.PersistentFlags().StringVarP(&networkName, "network", "n", "", "Named network from the config file (overridden by an explicit --rpc)")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Config file (default ~/.eth-rpc.yaml)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", OutputText, "Output format (text or json, indented on a terminal; csv for tabular commands such as balances; ndjson for streaming commands such as watch)")
+	rootCmd.PersistentFlags().StringVar(&outPath, "out", "", "Write the result to this file instead of stdout; progress and errors stay on stderr")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", "Go text/template applied to the result instead of the text output, e.g. '{{.Number}} {{.GasUsed}}'")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (automatic when stdout is not a terminal)")
+	rootCmd.PersistentFlags().StringVar(&amountUnit, "unit", UnitEther, "Unit for displayed amounts (wei, gwei or ether); gas prices default to gwei")
+	rootCmd.PersistentFlags().BoolVar(&utcTimes, "utc", false, "Show times in UTC instead of the local time zone")
+	rootCmd.PersistentFlags().IntVar(&etherDecimals, "decimals", 6, "Fractional digits for amounts in ether (-1 prints the exact value)")
+	rootCmd.PersistentFlags().StringVar(&multicallAddress, "multicall-address", defaultMulticallAddress, "Multicall3 contract used to batch reads")
+	rootCmd.PersistentFlags().DurationVar(&rpcTimeout, "timeout", 30*time.Second, "Overall deadline for the command's RPC calls (0 disables)")
+	rootCmd.PersistentFlags().IntVar(&rpcRetries, "retries", 3, "Retries for transient RPC failures (connection errors, HTTP 429/503)")
+	rootCmd.PersistentFlags().Float64Var(&rpcRate, "rate", 0, "Maximum RPC requests per second across all commands and workers (0 disables)")
+	rootCmd.PersistentFlags().StringVar(&cacheDir, "cache-dir", "", "Cache finalized blocks, transactions and receipts in this directory")
+	rootCmd.PersistentFlags().Int64Var(&cacheSizeMB, "cache-size", 256, "Maximum size of the --cache-dir cache in MB; least recently used entries are evicted")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Bypass the --cache-dir cache and re-fetch --abi-etherscan ABIs")
+	rootCmd.PersistentFlags().DurationVar(&rpcRetryDelay, "retry-delay", 500*time.Millisecond, "Initial delay between retries, doubled after each attempt")
+	rootCmd.PersistentFlags().IntVar(&breakerThreshold, "breaker-threshold", 5, "Consecutive transient failures within --breaker-window after which an endpoint is skipped (0 disables)")
+	rootCmd.PersistentFlags().DurationVar(&breakerWindow, "breaker-window", time.Minute, "Window in which failures count towards --breaker-threshold")
+	rootCmd.PersistentFlags().DurationVar(&breakerCooldown, "breaker-cooldown", 30*time.Second, "How long a failing endpoint is skipped before it is probed again")
+	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Log diagnostics, such as endpoint circuit breaker state changes, to stderr")
+
+	addPriceFlags(balanceCmd)
+	addExplorerFlag(balanceCmd)
+	balanceCmd.Flags().StringVar(&balanceBlock, "block", "", "Block number or tag (latest, pending, safe, finalized) to read at")
+
+	blockCmd.Flags().IntVar(&blockTop, "top", 0, "Also list the N highest-value transactions in the block")
+	addExplorerFlag(blockCmd)
 
 	rootCmd.AddCommand(infoCmd)
 	rootCmd.AddCommand(balanceCmd)
 	rootCmd.AddCommand(blockCmd)
+	rootCmd.AddCommand(blockHashCmd)
 }
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		fatal(err)
 	}
+	exit(0)
 }
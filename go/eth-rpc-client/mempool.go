@@ -0,0 +1,228 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mempoolAddresses   []string
+	mempoolConcurrency int
+	mempoolQueue       int
+)
+
+// mempoolDropReportInterval is how often the mempool command reports hashes
+// it had to drop because the fetch queue was full
+const mempoolDropReportInterval = 10 * time.Second
+
+// SubscribePendingTransactions streams the hashes of transactions entering
+// the node's mempool (eth_subscribe newPendingTransactions)
+func (c *Client) SubscribePendingTransactions(ch chan<- common.Hash) (ethereum.Subscription, error) {
+	if err := c.throttle(); err != nil {
+		return nil, err
+	}
+	sub, err := c.Client.Client().EthSubscribe(c.ctx, ch, "newPendingTransactions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to pending transactions: %w", err)
+	}
+	return sub, nil
+}
+
+// GetPendingTransaction returns a transaction by hash. It reports ok=false
+// when the node no longer knows it, e.g. because it was replaced.
+func (c *Client) GetPendingTransaction(hash common.Hash) (tx *types.Transaction, ok bool, err error) {
+	err = c.withRetry(func(ec *ethclient.Client) (err error) {
+		tx, _, err = ec.TransactionByHash(c.ctx, hash)
+		return err
+	})
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	return tx, true, nil
+}
+
+// PendingTxEvent is printed by the mempool command for each pending
+// transaction from or to a watched address. Value is in wei; To is empty for
+// contract creations. Direction is "in", "out" or "self".
+type PendingTxEvent struct {
+	Time      string `json:"time"`
+	Direction string `json:"direction"`
+	Hash      string `json:"hash"`
+	From      string `json:"from"`
+	To        string `json:"to,omitempty"`
+	Value     string `json:"value"`
+}
+
+func (e PendingTxEvent) renderText(w io.Writer) {
+	to := e.To
+	if to == "" {
+		to = "(contract creation)"
+	}
+	direction := strings.ToUpper(e.Direction)
+	if e.Direction == "in" {
+		direction = green(direction)
+	} else {
+		direction = red(direction)
+	}
+	fmt.Fprintf(w, "%s %s %s %s -> %s %s\n", cyan("["+e.Time+"]"), direction, e.Hash, e.From, to,
+		green(formatAmount(parseWei(e.Value), amountUnit)))
+}
+
+// pendingTxDirection returns the event direction for a transaction, or ""
+// when neither side is watched
+func pendingTxDirection(from common.Address, to *common.Address, watched map[common.Address]bool) string {
+	out := watched[from]
+	in := to != nil && watched[*to]
+	switch {
+	case in && out:
+		return "self"
+	case in:
+		return "in"
+	case out:
+		return "out"
+	}
+	return ""
+}
+
+var mempoolCmd = &cobra.Command{
+	Use:   "mempool",
+	Short: "Print pending transactions from or to an address (websocket or IPC endpoint required)",
+	Long: `Subscribes to the node's pending transaction hashes, fetches each with a
+bounded pool of --concurrency workers and prints the ones sent from or to any
+--address before they are mined. When hashes arrive faster than they can be
+fetched, up to --queue of them wait and the rest are dropped; the number
+dropped is reported periodically. Press Ctrl-C to stop.`,
+	Annotations: map[string]string{annotationLongRunning: "", annotationStreaming: ""},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := requireSubscriptions(rpcURLs); err != nil {
+			fatal(err)
+		}
+		if len(mempoolAddresses) == 0 {
+			fatal("at least one --address is required")
+		}
+		if mempoolConcurrency < 1 {
+			mempoolConcurrency = 1
+		}
+		if mempoolQueue < 0 {
+			fatal("--queue must not be negative")
+		}
+
+		watched := make(map[common.Address]bool)
+		for _, address := range mempoolAddresses {
+			addr, err := rpcClient.Resolve(address)
+			if err != nil {
+				fatal(err)
+			}
+			watched[addr] = true
+		}
+
+		chainID, err := rpcClient.GetChainID()
+		if err != nil {
+			fatal(err)
+		}
+		signer := types.LatestSignerForChainID(chainID)
+
+		hashes := make(chan common.Hash, 128)
+		sub, err := rpcClient.SubscribePendingTransactions(hashes)
+		if err != nil {
+			fatal(err)
+		}
+		defer sub.Unsubscribe()
+
+		var (
+			jobs    = make(chan common.Hash, mempoolQueue)
+			matches = make(chan PendingTxEvent)
+			wg      sync.WaitGroup
+		)
+		for w := 0; w < mempoolConcurrency; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for hash := range jobs {
+					tx, ok, err := rpcClient.GetPendingTransaction(hash)
+					if err != nil || !ok {
+						continue
+					}
+					from, err := types.Sender(signer, tx)
+					if err != nil {
+						continue
+					}
+					direction := pendingTxDirection(from, tx.To(), watched)
+					if direction == "" {
+						continue
+					}
+
+					event := PendingTxEvent{
+						Time:      displayTime(time.Now()).Format(time.RFC3339),
+						Direction: direction,
+						Hash:      hash.Hex(),
+						From:      from.Hex(),
+						Value:     tx.Value().String(),
+					}
+					if tx.To() != nil {
+						event.To = tx.To().Hex()
+					}
+					select {
+					case matches <- event:
+					case <-rpcClient.ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+		defer func() {
+			close(jobs)
+			wg.Wait()
+		}()
+
+		ticker := time.NewTicker(mempoolDropReportInterval)
+		defer ticker.Stop()
+
+		dropped := 0
+		for {
+			select {
+			case <-rpcClient.ctx.Done():
+				return
+			case err := <-sub.Err():
+				fatalf("subscription failed: %v", err)
+			case hash := <-hashes:
+				// Never block here: a subscription that is not drained fast
+				// enough is closed by the node
+				select {
+				case jobs <- hash:
+				default:
+					dropped++
+				}
+			case event := <-matches:
+				renderEvent(event)
+			case <-ticker.C:
+				if dropped > 0 {
+					fmt.Fprintf(progressWriter(), "Note: dropped %d pending transactions; the fetch queue was full (raise --concurrency or --queue)\n", dropped)
+					dropped = 0
+				}
+			}
+		}
+	},
+}
+
+func init() {
+	mempoolCmd.Flags().StringArrayVar(&mempoolAddresses, "address", nil, "Address or ENS name to watch (repeatable)")
+	mempoolCmd.Flags().IntVar(&mempoolConcurrency, "concurrency", 8, "Maximum number of concurrent transaction fetches")
+	mempoolCmd.Flags().IntVar(&mempoolQueue, "queue", 1000, "Pending hashes to queue before dropping new ones")
+
+	rootCmd.AddCommand(mempoolCmd)
+}
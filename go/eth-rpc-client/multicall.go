@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// defaultMulticallAddress is the canonical Multicall3 deployment, at the same
+// address on most chains
+const defaultMulticallAddress = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+// multicallBatchSize bounds the number of calls aggregated into one eth_call
+// so a large batch stays under the node's gas cap for calls
+const multicallBatchSize = 500
+
+var multicallAddress string
+
+const multicall3ABIJSON = `[
+	{"inputs":[{"components":[{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}],"name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}],"name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"},
+	{"inputs":[{"name":"addr","type":"address"}],"name":"getEthBalance","outputs":[{"name":"balance","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+var multicall3ABI = mustParseABI(multicall3ABIJSON)
+
+// Call is a single read-only contract call to aggregate with Multicall
+type Call struct {
+	Target   common.Address
+	CallData []byte
+}
+
+// MulticallResult is the outcome of one aggregated call
+type MulticallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Multicall executes read-only calls through Multicall3's aggregate3 in as few
+// eth_calls as possible. Individual calls may fail without failing the batch;
+// check Success on each result.
+func (c *Client) Multicall(calls []Call) ([]MulticallResult, error) {
+	if !common.IsHexAddress(multicallAddress) {
+		return nil, fmt.Errorf("invalid multicall address %q", multicallAddress)
+	}
+	contract := common.HexToAddress(multicallAddress)
+
+	results := make([]MulticallResult, 0, len(calls))
+	for start := 0; start < len(calls); start += multicallBatchSize {
+		end := min(start+multicallBatchSize, len(calls))
+
+		type call3 struct {
+			Target       common.Address
+			AllowFailure bool
+			CallData     []byte
+		}
+		batch := make([]call3, 0, end-start)
+		for _, call := range calls[start:end] {
+			batch = append(batch, call3{Target: call.Target, AllowFailure: true, CallData: call.CallData})
+		}
+
+		values, err := c.CallFunction(contract, multicall3ABI, "aggregate3", []any{batch}, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("multicall failed: %w", err)
+		}
+		batchResults := *abi.ConvertType(values[0], new([]MulticallResult)).(*[]MulticallResult)
+		if len(batchResults) != end-start {
+			return nil, fmt.Errorf("multicall returned %d results for %d calls", len(batchResults), end-start)
+		}
+		results = append(results, batchResults...)
+	}
+	return results, nil
+}
+
+// multicallUint256 aggregates calls that each return a single uint256. A
+// failed or malformed call leaves a nil entry with its error set.
+func (c *Client) multicallUint256(calls []Call) ([]*big.Int, []error, error) {
+	results, err := c.Multicall(calls)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make([]*big.Int, len(results))
+	errs := make([]error, len(results))
+	for i, result := range results {
+		if !result.Success || len(result.ReturnData) != 32 {
+			errs[i] = fmt.Errorf("call to %s failed", calls[i].Target.Hex())
+			continue
+		}
+		values[i] = new(big.Int).SetBytes(result.ReturnData)
+	}
+	return values, errs, nil
+}
+
+// resolveOffline resolves a hex address or address-book alias without any RPC
+// call, reporting false for names that need ENS resolution
+func resolveOffline(nameOrAddr string) (common.Address, bool) {
+	if common.IsHexAddress(nameOrAddr) {
+		return common.HexToAddress(nameOrAddr), true
+	}
+	return lookupAlias(nameOrAddr)
+}
+
+// getBalancesMulticall looks up ETH balances with Multicall3's getEthBalance,
+// one round trip per batch. It reports false when an input needs ENS
+// resolution or multicall is unavailable, so the caller can fall back.
+func (c *Client) getBalancesMulticall(addresses []string) ([]BalanceLookup, bool) {
+	contract := common.HexToAddress(multicallAddress)
+
+	calls := make([]Call, len(addresses))
+	for i, input := range addresses {
+		addr, ok := resolveOffline(input)
+		if !ok {
+			return nil, false
+		}
+		data, err := multicall3ABI.Pack("getEthBalance", addr)
+		if err != nil {
+			return nil, false
+		}
+		calls[i] = Call{Target: contract, CallData: data}
+	}
+
+	balances, errs, err := c.multicallUint256(calls)
+	if err != nil {
+		return nil, false
+	}
+
+	results := make([]BalanceLookup, len(addresses))
+	for i, input := range addresses {
+		results[i] = BalanceLookup{Input: input, Balance: balances[i], Err: errs[i]}
+	}
+	return results, true
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// GetNetworkID returns the node's network ID from net_version
+func (c *Client) GetNetworkID() (*big.Int, error) {
+	var version string
+	err := c.withRetry(func(ec *ethclient.Client) error {
+		return ec.Client().CallContext(c.ctx, &version, "net_version")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network ID: %w", err)
+	}
+
+	id, ok := new(big.Int).SetString(version, 0)
+	if !ok {
+		return nil, fmt.Errorf("node returned a non-numeric network ID %q", version)
+	}
+	return id, nil
+}
+
+// NetworkIDInfo is the result of the network-id command
+type NetworkIDInfo struct {
+	ChainID   string `json:"chainId"`
+	NetworkID string `json:"networkId"`
+	Match     bool   `json:"match"`
+}
+
+func (n NetworkIDInfo) renderText(w io.Writer) {
+	printField(w, "Chain ID", n.ChainID)
+	printField(w, "Network ID", n.NetworkID)
+	if !n.Match {
+		fmt.Fprintln(w, red("Warning: chain ID and network ID differ; check the node's configuration"))
+	}
+}
+
+var networkIDCmd = &cobra.Command{
+	Use:   "network-id",
+	Short: "Show the network ID (net_version) alongside the chain ID",
+	Long: `Shows eth_chainId and net_version side by side. They are equal on most
+networks; a mismatch can point to a misconfigured node or a chain where the
+two intentionally differ.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		chainID, err := rpcClient.GetChainID()
+		if err != nil {
+			fatal(err)
+		}
+		networkID, err := rpcClient.GetNetworkID()
+		if err != nil {
+			fatal(err)
+		}
+
+		render(NetworkIDInfo{
+			ChainID:   chainID.String(),
+			NetworkID: networkID.String(),
+			Match:     chainID.Cmp(networkID) == 0,
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(networkIDCmd)
+}
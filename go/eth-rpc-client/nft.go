@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+const erc721ABIJSON = `[
+	{"constant":true,"inputs":[{"name":"tokenId","type":"uint256"}],"name":"ownerOf","outputs":[{"name":"","type":"address"}],"type":"function"},
+	{"constant":true,"inputs":[{"name":"tokenId","type":"uint256"}],"name":"tokenURI","outputs":[{"name":"","type":"string"}],"type":"function"}
+]`
+
+var erc721ABI = mustParseABI(erc721ABIJSON)
+
+// GetNFTOwner returns the owner of an ERC-721 token
+func (c *Client) GetNFTOwner(contract string, tokenID *big.Int) (common.Address, error) {
+	addr, err := c.Resolve(contract)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	values, err := c.callMethod(addr, erc721ABI, "ownerOf", tokenID)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get owner of token %s: %w", tokenID, err)
+	}
+	return values[0].(common.Address), nil
+}
+
+// GetNFTTokenURI returns the metadata URI of an ERC-721 token exactly as the
+// contract reports it, including ipfs:// and data: URIs
+func (c *Client) GetNFTTokenURI(contract string, tokenID *big.Int) (string, error) {
+	addr, err := c.Resolve(contract)
+	if err != nil {
+		return "", err
+	}
+
+	values, err := c.callMethod(addr, erc721ABI, "tokenURI", tokenID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get URI of token %s: %w", tokenID, err)
+	}
+	return values[0].(string), nil
+}
+
+// parseTokenID parses an NFT token ID given in decimal or 0x-prefixed hex
+func parseTokenID(s string) (*big.Int, error) {
+	id, ok := new(big.Int).SetString(s, 0)
+	if !ok || id.Sign() < 0 || id.BitLen() > 256 {
+		return nil, fmt.Errorf("invalid token ID %q", s)
+	}
+	return id, nil
+}
+
+// NFTInfo is the result of the nft subcommands
+type NFTInfo struct {
+	Contract string `json:"contract"`
+	TokenID  string `json:"tokenId"`
+	Owner    string `json:"owner,omitempty"`
+	URI      string `json:"uri,omitempty"`
+}
+
+func (n NFTInfo) renderText(w io.Writer) {
+	if n.Owner != "" {
+		printField(w, "Owner", n.Owner)
+	}
+	if n.URI != "" {
+		printField(w, "Token URI", n.URI)
+	}
+}
+
+var nftCmd = &cobra.Command{
+	Use:   "nft",
+	Short: "Query ERC-721 tokens",
+}
+
+var nftOwnerCmd = &cobra.Command{
+	Use:   "owner [contract] [tokenId]",
+	Short: "Get the owner of an ERC-721 token",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		tokenID, err := parseTokenID(args[1])
+		if err != nil {
+			fatal(err)
+		}
+
+		contract, err := rpcClient.Resolve(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		owner, err := rpcClient.GetNFTOwner(contract.Hex(), tokenID)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(NFTInfo{Contract: contract.Hex(), TokenID: tokenID.String(), Owner: owner.Hex()})
+	},
+}
+
+var nftURICmd = &cobra.Command{
+	Use:   "uri [contract] [tokenId]",
+	Short: "Get the metadata URI of an ERC-721 token",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		tokenID, err := parseTokenID(args[1])
+		if err != nil {
+			fatal(err)
+		}
+
+		contract, err := rpcClient.Resolve(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		uri, err := rpcClient.GetNFTTokenURI(contract.Hex(), tokenID)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(NFTInfo{Contract: contract.Hex(), TokenID: tokenID.String(), URI: uri})
+	},
+}
+
+func init() {
+	nftCmd.AddCommand(nftOwnerCmd)
+	nftCmd.AddCommand(nftURICmd)
+	rootCmd.AddCommand(nftCmd)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var noncePending bool
+
+// GetNonce returns the confirmed nonce of an address, or the pending nonce
+// including transactions still in the mempool when pending is true
+func (c *Client) GetNonce(address string, pending bool) (uint64, error) {
+	addr, err := c.Resolve(address)
+	if err != nil {
+		return 0, err
+	}
+
+	var nonce uint64
+	err = c.withRetry(func(ec *ethclient.Client) (err error) {
+		if pending {
+			nonce, err = ec.PendingNonceAt(c.ctx, addr)
+		} else {
+			nonce, err = ec.NonceAt(c.ctx, addr, nil)
+		}
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	return nonce, nil
+}
+
+// NonceInfo is the result of the nonce command
+type NonceInfo struct {
+	Address string  `json:"address"`
+	Nonce   uint64  `json:"nonce"`
+	Pending *uint64 `json:"pending,omitempty"`
+}
+
+func (n NonceInfo) renderText(w io.Writer) {
+	printField(w, "Nonce", n.Nonce)
+	if n.Pending != nil {
+		printField(w, "Pending Nonce", *n.Pending)
+		if *n.Pending > n.Nonce {
+			printField(w, "Pending Transactions", *n.Pending-n.Nonce)
+		}
+	}
+}
+
+var nonceCmd = &cobra.Command{
+	Use:   "nonce [address]",
+	Short: "Get the transaction count (nonce) of an address",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		addr, err := rpcClient.Resolve(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		nonce, err := rpcClient.GetNonce(addr.Hex(), false)
+		if err != nil {
+			fatal(err)
+		}
+
+		info := NonceInfo{Address: addr.Hex(), Nonce: nonce}
+		if noncePending {
+			pending, err := rpcClient.GetNonce(addr.Hex(), true)
+			if err != nil {
+				fatal(err)
+			}
+			info.Pending = &pending
+		}
+
+		render(info)
+	},
+}
+
+func init() {
+	nonceCmd.Flags().BoolVar(&noncePending, "pending", false, "Also show the pending nonce, including mempool transactions")
+
+	rootCmd.AddCommand(nonceCmd)
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// NonceTx is a transaction in the node's pool, identified by nonce
+type NonceTx struct {
+	Nonce uint64 `json:"nonce"`
+	Hash  string `json:"hash"`
+}
+
+// GetPoolTransactions returns an address's transactions in the node's pool
+// (txpool_contentFrom), sorted by nonce: pending ones can be mined in order,
+// queued ones wait behind a missing nonce. It returns errMethodUnsupported
+// when the node does not expose its pool, as most hosted providers do not.
+func (c *Client) GetPoolTransactions(addr common.Address) (pending, queued []NonceTx, err error) {
+	type poolTx struct {
+		Hash  common.Hash    `json:"hash"`
+		Nonce hexutil.Uint64 `json:"nonce"`
+	}
+	var content struct {
+		Pending map[string]poolTx `json:"pending"`
+		Queued  map[string]poolTx `json:"queued"`
+	}
+	err = c.withRetry(func(ec *ethclient.Client) error {
+		return ec.Client().CallContext(c.ctx, &content, "txpool_contentFrom", addr)
+	})
+	if err != nil && isMethodNotFound(err) {
+		return nil, nil, fmt.Errorf("txpool_contentFrom: %w", errMethodUnsupported)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pool transactions: %w", err)
+	}
+
+	collect := func(txs map[string]poolTx) []NonceTx {
+		list := make([]NonceTx, 0, len(txs))
+		for _, tx := range txs {
+			list = append(list, NonceTx{Nonce: uint64(tx.Nonce), Hash: tx.Hash.Hex()})
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Nonce < list[j].Nonce })
+		return list
+	}
+	return collect(content.Pending), collect(content.Queued), nil
+}
+
+// NonceRange is an inclusive range of nonces
+type NonceRange struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+func (r NonceRange) String() string {
+	if r.From == r.To {
+		return fmt.Sprint(r.From)
+	}
+	return fmt.Sprintf("%d-%d", r.From, r.To)
+}
+
+// missingNonces returns the nonces from confirmed up to the highest pool
+// transaction that no pool transaction uses
+func missingNonces(confirmed uint64, pending, queued []NonceTx) []NonceRange {
+	txs := append(append([]NonceTx{}, pending...), queued...)
+	sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+
+	var missing []NonceRange
+	next := confirmed
+	for _, tx := range txs {
+		if tx.Nonce > next {
+			missing = append(missing, NonceRange{From: next, To: tx.Nonce - 1})
+		}
+		next = max(next, tx.Nonce+1)
+	}
+	return missing
+}
+
+// NonceGapReport is the result of the nonce-gaps command. Confirmed is the
+// next nonce to be mined and PendingNonce the next one after the node's
+// executable pending transactions. The pool fields are only set when the node
+// exposes its transaction pool.
+type NonceGapReport struct {
+	Address       string       `json:"address"`
+	Confirmed     uint64       `json:"confirmedNonce"`
+	PendingNonce  uint64       `json:"pendingNonce"`
+	PoolAvailable bool         `json:"poolAvailable"`
+	Pending       []NonceTx    `json:"pending,omitempty"`
+	Queued        []NonceTx    `json:"queued,omitempty"`
+	Missing       []NonceRange `json:"missing,omitempty"`
+	Gap           bool         `json:"gap"`
+	Advice        string       `json:"advice"`
+}
+
+func (r NonceGapReport) summary() string {
+	parts := []string{fmt.Sprintf("Confirmed nonce %d", r.Confirmed)}
+	if r.PendingNonce > r.Confirmed {
+		parts = append(parts, fmt.Sprintf("pending up to %d", r.PendingNonce-1))
+	}
+	if len(r.Queued) > 0 {
+		parts = append(parts, fmt.Sprintf("%d queued", len(r.Queued)))
+	}
+	switch {
+	case r.Gap:
+		parts = append(parts, red("gap detected"))
+	case r.PoolAvailable:
+		parts = append(parts, green("no gaps"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (r NonceGapReport) renderText(w io.Writer) {
+	printField(w, "Address", r.Address)
+	fmt.Fprintln(w, r.summary())
+	for _, tx := range r.Pending {
+		printField(w, fmt.Sprintf("  Pending %d", tx.Nonce), tx.Hash)
+	}
+	for _, tx := range r.Queued {
+		printField(w, fmt.Sprintf("  Queued %d", tx.Nonce), tx.Hash)
+	}
+	for _, gap := range r.Missing {
+		fmt.Fprintf(w, "  %s %s\n", red("Missing"), gap)
+	}
+	fmt.Fprintln(w, r.Advice)
+}
+
+// nonceGapAdvice says what to do about the state the report describes
+func nonceGapAdvice(r NonceGapReport) string {
+	switch {
+	case r.Gap:
+		missing := make([]string, len(r.Missing))
+		for i, gap := range r.Missing {
+			missing[i] = gap.String()
+		}
+		return fmt.Sprintf("Queued transactions cannot be mined until the missing nonces (%s) are used. Send a transaction with each missing nonce, e.g. a 0 ETH transfer to yourself, or wait for them to be dropped from the pool.",
+			strings.Join(missing, ", "))
+	case len(r.Pending) > 0:
+		return fmt.Sprintf("No gaps. If the pending transactions are stuck, speed up the oldest with: eth-rpc replace %s", r.Pending[0].Hash)
+	case r.PendingNonce > r.Confirmed && !r.PoolAvailable:
+		return fmt.Sprintf("%d transactions are waiting to be mined. The node does not expose its transaction pool, so queued transactions and gaps behind them cannot be seen; try a node that supports txpool_contentFrom.",
+			r.PendingNonce-r.Confirmed)
+	case !r.PoolAvailable:
+		return "No pending transactions. The node does not expose its transaction pool, so queued transactions cannot be seen."
+	}
+	return "No pending transactions."
+}
+
+var nonceGapsCmd = &cobra.Command{
+	Use:   "nonce-gaps [address]",
+	Short: "Find missing nonces holding back an address's transactions",
+	Long: `Compares an address's confirmed and pending nonces and, when the node exposes
+its transaction pool (txpool_contentFrom, e.g. geth), lists the address's
+pending and queued transactions. A transaction is queued when a lower nonce is
+missing; it cannot be mined until that nonce is used. The report ends with
+what to do: fill the gap, or speed up a stuck transaction with replace.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		addr, err := rpcClient.Resolve(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		confirmed, err := rpcClient.GetNonce(addr.Hex(), false)
+		if err != nil {
+			fatal(err)
+		}
+		pendingNonce, err := rpcClient.GetNonce(addr.Hex(), true)
+		if err != nil {
+			fatal(err)
+		}
+
+		report := NonceGapReport{Address: addr.Hex(), Confirmed: confirmed, PendingNonce: pendingNonce}
+		pending, queued, err := rpcClient.GetPoolTransactions(addr)
+		switch {
+		case errors.Is(err, errMethodUnsupported):
+		case err != nil:
+			fatal(err)
+		default:
+			report.PoolAvailable = true
+			report.Pending, report.Queued = pending, queued
+			report.Missing = missingNonces(confirmed, pending, queued)
+			report.Gap = len(report.Missing) > 0
+		}
+		report.Advice = nonceGapAdvice(report)
+
+		render(report)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(nonceGapsCmd)
+}
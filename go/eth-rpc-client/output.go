@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Output formats accepted by --output
+const (
+	OutputText   = "text"
+	OutputJSON   = "json"
+	OutputCSV    = "csv"
+	OutputNDJSON = "ndjson"
+)
+
+const (
+	// annotationCSV marks commands whose results can be rendered as CSV
+	annotationCSV = "csv"
+
+	// annotationStreaming marks commands that print a stream of events and so
+	// support --output ndjson. Like annotationLongRunning, an empty value
+	// means always; otherwise it names the bool flag that makes the command
+	// stream, e.g. "follow".
+	annotationStreaming = "streaming"
+)
+
+var (
+	outputFormat string
+	noColor      bool
+	verbose      bool
+	outPath      string
+
+	// jsonPretty and jsonColor are set by validateOutput for --output json:
+	// indented JSON for a terminal, colored unless color is disabled, and
+	// compact single-line JSON when piped or written to a file
+	jsonPretty bool
+	jsonColor  bool
+
+	// resultOut is where results are rendered: stdout, or the --out file.
+	// The file is unbuffered, so whatever a command rendered before failing
+	// is already on disk.
+	resultOut io.Writer = os.Stdout
+)
+
+var (
+	cyan  = color.New(color.FgCyan).SprintFunc()
+	green = color.New(color.FgGreen).SprintFunc()
+	red   = color.New(color.FgRed).SprintFunc()
+
+	// JSON is colored by validateOutput's decision rather than color.NoColor,
+	// which JSON mode sets so that no text helper emits escape codes
+	jsonKey   = forcedColor(color.FgCyan)
+	jsonValue = forcedColor(color.FgGreen)
+)
+
+// forcedColor returns a color function that ignores color.NoColor
+func forcedColor(attr color.Attribute) func(a ...any) string {
+	c := color.New(attr)
+	c.EnableColor()
+	return c.SprintFunc()
+}
+
+// textRenderer is implemented by command results that have a human-readable form
+type textRenderer interface {
+	renderText(w io.Writer)
+}
+
+// csvRenderer is implemented by tabular command results that support --output csv
+type csvRenderer interface {
+	renderCSV(w *csv.Writer)
+}
+
+// validateOutput checks the --output flag against what the command supports
+// and disables color for machine-readable formats, with --no-color, or when
+// stdout is not a terminal. JSON for a terminal is still pretty-printed and,
+// unless color is disabled, colored. NDJSON is always compact.
+func validateOutput(cmd *cobra.Command) error {
+	switch outputFormat {
+	case OutputText:
+	case OutputJSON:
+		jsonPretty = resultsToTerminal()
+		jsonColor = jsonPretty && !noColor && !color.NoColor
+		color.NoColor = true
+	case OutputCSV:
+		if _, ok := cmd.Annotations[annotationCSV]; !ok {
+			return fmt.Errorf("%s does not support --output %s", cmd.CommandPath(), OutputCSV)
+		}
+		color.NoColor = true
+	case OutputNDJSON:
+		if flag := cmd.Annotations[annotationStreaming]; flag != "" && !annotationEnabled(cmd, annotationStreaming) {
+			return fmt.Errorf("%s only streams events with --%s, so --output %s needs it", cmd.CommandPath(), flag, OutputNDJSON)
+		}
+		if !annotationEnabled(cmd, annotationStreaming) {
+			return fmt.Errorf("%s does not stream events; use --output %s", cmd.CommandPath(), OutputJSON)
+		}
+		color.NoColor = true
+	default:
+		return fmt.Errorf("invalid output format %q (expected %s, %s, %s or %s)", outputFormat, OutputText, OutputJSON, OutputCSV, OutputNDJSON)
+	}
+
+	if noColor || !resultsToTerminal() {
+		color.NoColor = true
+	}
+	return parseOutputTemplate()
+}
+
+// resultsToTerminal reports whether results are printed to a terminal rather
+// than redirected to a file or pipe
+func resultsToTerminal() bool {
+	return outPath == "" && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// openOutput creates or truncates the --out file and renders results to it
+func openOutput() error {
+	if outPath == "" {
+		return nil
+	}
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create --out file: %w", err)
+	}
+	resultOut = &outFile{f: f}
+	return nil
+}
+
+// outFile is the --out file. It keeps the first write error, since results
+// are printed with fmt.Fprint* calls that do not check one.
+type outFile struct {
+	f   *os.File
+	err error
+}
+
+func (o *outFile) Write(p []byte) (int, error) {
+	n, err := o.f.Write(p)
+	if err != nil && o.err == nil {
+		o.err = err
+	}
+	return n, err
+}
+
+// closeOutput closes the --out file, reporting a failed write or close
+func closeOutput() error {
+	o, ok := resultOut.(*outFile)
+	if !ok {
+		return nil
+	}
+	resultOut = os.Stdout
+	err := o.f.Close()
+	if o.err != nil {
+		err = o.err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write --out file: %w", err)
+	}
+	return nil
+}
+
+// render prints a command result to stdout, or the --out file, in the
+// selected output format, or through --template when one is given
+func render(v any) {
+	if parsedTemplate != nil {
+		renderTemplate(v)
+		return
+	}
+	if outputFormat == OutputJSON || outputFormat == OutputNDJSON {
+		writeJSON(v, jsonPretty)
+		return
+	}
+
+	if r, ok := v.(csvRenderer); ok && outputFormat == OutputCSV {
+		w := csv.NewWriter(resultOut)
+		r.renderCSV(w)
+		w.Flush()
+		if err := w.Error(); err != nil {
+			fatal(err)
+		}
+		return
+	}
+
+	if r, ok := v.(textRenderer); ok {
+		r.renderText(resultOut)
+		return
+	}
+	fmt.Fprintln(resultOut, v)
+}
+
+// renderEvent prints one event of a streaming command. In JSON and NDJSON mode
+// each event is a single compact line, written to the unbuffered stdout or
+// --out file in one call, so consumers can process it immediately and an
+// interrupt never leaves half a line behind.
+func renderEvent(v any) {
+	if outputFormat == OutputJSON || outputFormat == OutputNDJSON {
+		writeJSON(v, false)
+		return
+	}
+	render(v)
+}
+
+// writeJSON writes v as one JSON document, indented when pretty is set and
+// colored when jsonColor is. Amounts such as wei and token supplies are
+// strings in every result type, since JSON numbers lose precision past 2^53
+// in JavaScript consumers.
+func writeJSON(v any, pretty bool) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		fatal(err)
+	}
+	if pretty {
+		var buf bytes.Buffer
+		json.Indent(&buf, data, "", "  ")
+		data = buf.Bytes()
+	}
+	if jsonColor {
+		data = colorizeJSON(data)
+	}
+	if _, err := resultOut.Write(append(data, '\n')); err != nil {
+		fatal(err)
+	}
+}
+
+// jsonDelimiters are the bytes that separate tokens in marshaled JSON
+const jsonDelimiters = "{}[],: \n"
+
+// colorizeJSON colors the object keys and the values of marshaled JSON,
+// leaving punctuation and whitespace as they are
+func colorizeJSON(data []byte) []byte {
+	var out bytes.Buffer
+	for i := 0; i < len(data); {
+		switch c := data[i]; {
+		case c == '"':
+			end := i + 1
+			for data[end] != '"' {
+				if data[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end++
+			if end < len(data) && data[end] == ':' {
+				out.WriteString(jsonKey(string(data[i:end])))
+			} else {
+				out.WriteString(jsonValue(string(data[i:end])))
+			}
+			i = end
+		case strings.IndexByte(jsonDelimiters, c) >= 0:
+			out.WriteByte(c)
+			i++
+		default:
+			end := i
+			for end < len(data) && strings.IndexByte(jsonDelimiters, data[end]) < 0 {
+				end++
+			}
+			out.WriteString(jsonValue(string(data[i:end])))
+			i = end
+		}
+	}
+	return out.Bytes()
+}
+
+// printField prints a colored "Label: value" line
+func printField(w io.Writer, label string, value any) {
+	fmt.Fprintf(w, "%s %s\n", cyan(label+":"), green(value))
+}
+
+// progressWriter returns where progress messages go: stdout for text output,
+// stderr for machine-readable or --template output, or when results go to an
+// --out file, so it does not corrupt the result
+func progressWriter() io.Writer {
+	if outputFormat == OutputText && parsedTemplate == nil && outPath == "" {
+		return os.Stdout
+	}
+	return os.Stderr
+}
+
+// logVerbose logs a diagnostic message to stderr when --verbose is set
+func logVerbose(format string, args ...any) {
+	if verbose {
+		log.Printf(format, args...)
+	}
+}
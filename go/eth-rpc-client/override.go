@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// stateOverride is the optional third parameter of eth_call: per-address
+// replacements for account state that apply to that call only
+type stateOverride map[common.Address]overrideAccount
+
+// overrideAccount replaces parts of an account's state. State replaces the
+// whole storage, StateDiff only the listed slots.
+type overrideAccount struct {
+	Balance   *hexutil.Big                `json:"balance,omitempty"`
+	Nonce     *hexutil.Uint64             `json:"nonce,omitempty"`
+	Code      *hexutil.Bytes              `json:"code,omitempty"`
+	State     map[common.Hash]common.Hash `json:"state,omitempty"`
+	StateDiff map[common.Hash]common.Hash `json:"stateDiff,omitempty"`
+}
+
+// loadStateOverride parses a state override given as JSON or as the path of
+// a JSON file, e.g. {"0x...": {"balance": "0xde0b6b3a7640000"}}. Quantities
+// are 0x hex as in the RPC API.
+func loadStateOverride(value string) (stateOverride, error) {
+	if data, err := os.ReadFile(value); err == nil {
+		value = string(data)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(value))
+	dec.DisallowUnknownFields()
+	var overrides stateOverride
+	if err := dec.Decode(&overrides); err != nil {
+		return nil, fmt.Errorf("invalid --override: %w", err)
+	}
+	for addr, account := range overrides {
+		if account.State != nil && account.StateDiff != nil {
+			return nil, fmt.Errorf("invalid --override: %s sets both state and stateDiff", addr.Hex())
+		}
+	}
+	return overrides, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/cobra"
+)
+
+// errMethodUnsupported is returned when the endpoint does not expose an RPC method
+var errMethodUnsupported = errors.New("method not supported by this endpoint")
+
+// isMethodNotFound reports whether an RPC error means the method is unavailable,
+// either because the node does not implement it or a gateway blocks it
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "method not found") ||
+		strings.Contains(msg, "does not exist/is not available") ||
+		strings.Contains(msg, "not supported")
+}
+
+// PeerInfo is the result of the peers command
+type PeerInfo struct {
+	Peers uint64 `json:"peers"`
+}
+
+func (p PeerInfo) renderText(w io.Writer) {
+	printField(w, "Peers", p.Peers)
+}
+
+var peersCmd = &cobra.Command{
+	Use:   "peers",
+	Short: "Show the node's connected peer count (net_peerCount)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		peers, err := rpcClient.GetPeerCount()
+		if errors.Is(err, errMethodUnsupported) {
+			fatal("this endpoint does not expose net_peerCount; public gateways usually hide it")
+		}
+		if err != nil {
+			fatal(err)
+		}
+
+		render(PeerInfo{Peers: peers})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(peersCmd)
+}
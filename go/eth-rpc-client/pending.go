@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/cobra"
+)
+
+// errNoPendingBlock is returned by GetPendingBlock when the endpoint does not
+// build a pending block
+var errNoPendingBlock = errors.New("this endpoint does not build a pending block")
+
+// GetPendingBlock returns the block the node is building on top of the
+// latest one. Nodes that do not build one return nothing or, commonly, the
+// latest block itself; both are reported as errNoPendingBlock.
+func (c *Client) GetPendingBlock() (*types.Block, error) {
+	block, err := c.GetBlockAt(big.NewInt(int64(rpc.PendingBlockNumber)))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, errNoPendingBlock
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := c.GetBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	if block.Number() == nil || block.NumberU64() <= latest {
+		return nil, errNoPendingBlock
+	}
+	return block, nil
+}
+
+// PendingInfo is the result of the pending command. BaseFee is the pending
+// block's own base fee and NextBaseFee the one projected for the block after
+// it, both in wei.
+type PendingInfo struct {
+	Number         uint64  `json:"number"`
+	Transactions   int     `json:"transactions"`
+	GasUsed        uint64  `json:"gasUsed"`
+	GasLimit       uint64  `json:"gasLimit"`
+	GasUsedPercent float64 `json:"gasUsedPercent"`
+	BaseFee        string  `json:"baseFee,omitempty"`
+	NextBaseFee    string  `json:"nextBaseFee,omitempty"`
+}
+
+func (p PendingInfo) renderText(w io.Writer) {
+	printField(w, "Pending Block", p.Number)
+	printField(w, "Transactions", p.Transactions)
+	printField(w, "Gas Used", fmt.Sprintf("%d (%.1f%%)", p.GasUsed, p.GasUsedPercent))
+	printField(w, "Gas Limit", p.GasLimit)
+	if p.BaseFee != "" {
+		printField(w, "Base Fee", formatAmount(parseWei(p.BaseFee), gasPriceUnit()))
+		printField(w, "Next Base Fee", formatAmount(parseWei(p.NextBaseFee), gasPriceUnit()))
+	}
+}
+
+var pendingCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "Preview the pending block",
+	Long: `Fetches the block the node is currently building and prints how many
+transactions it holds, the gas they use and the base fee it and the following
+block will pay. Many endpoints, including most hosted providers, do not build
+a pending block; the command says so and exits non-zero.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		block, err := rpcClient.GetPendingBlock()
+		if err != nil {
+			fatal(err)
+		}
+
+		info := PendingInfo{
+			Number:       block.NumberU64(),
+			Transactions: len(block.Transactions()),
+			GasUsed:      block.GasUsed(),
+			GasLimit:     block.GasLimit(),
+		}
+		if block.GasLimit() > 0 {
+			info.GasUsedPercent = float64(block.GasUsed()) / float64(block.GasLimit()) * 100
+		}
+		if baseFee := block.BaseFee(); baseFee != nil {
+			info.BaseFee = baseFee.String()
+			info.NextBaseFee = nextBaseFee(block.GasUsed(), block.GasLimit(), baseFee).String()
+		}
+
+		render(info)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pendingCmd)
+}
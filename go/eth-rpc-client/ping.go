@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/cobra"
+)
+
+// pingTimeout bounds each endpoint's ping, so an unreachable endpoint fails
+// the --check preflight quickly instead of after the command's --timeout
+const pingTimeout = 10 * time.Second
+
+// Kinds of ping failure. DNS and connect failures mean the endpoint was never
+// reached; http and rpc ones mean it was reached but did not answer
+// eth_chainId.
+const (
+	pingErrDNS     = "dns"
+	pingErrConnect = "connect"
+	pingErrTLS     = "tls"
+	pingErrTimeout = "timeout"
+	pingErrHTTP    = "http"
+	pingErrRPC     = "rpc"
+)
+
+var preflightCheck bool
+
+// PingResult is the outcome of pinging one endpoint. Latency covers
+// connecting and the eth_chainId round trip, in milliseconds.
+type PingResult struct {
+	URL       string  `json:"url"`
+	OK        bool    `json:"ok"`
+	ChainID   string  `json:"chainId,omitempty"`
+	LatencyMs float64 `json:"latencyMs"`
+	ErrorKind string  `json:"errorKind,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+func (p PingResult) String() string {
+	if !p.OK {
+		return fmt.Sprintf("%s: %s error: %s", p.URL, p.ErrorKind, p.Error)
+	}
+	chainID, _ := new(big.Int).SetString(p.ChainID, 10)
+	return fmt.Sprintf("%s: %s in %.1fms", p.URL, describeChain(chainID), p.LatencyMs)
+}
+
+// PingResults is the result of the ping command, one entry per --rpc endpoint
+type PingResults []PingResult
+
+func (r PingResults) renderText(w io.Writer) {
+	for _, p := range r {
+		status := green("ok")
+		if !p.OK {
+			status = red("FAIL")
+		}
+		fmt.Fprintf(w, "%s %s\n", status, p)
+	}
+}
+
+// classifyPingError names the stage a ping failed at and strips the request
+// URL from the error, since URLs often embed API keys
+func classifyPingError(err error) (string, error) {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+
+	var (
+		dnsErr  *net.DNSError
+		opErr   *net.OpError
+		netErr  net.Error
+		httpErr rpc.HTTPError
+		rpcErr  rpc.Error
+	)
+	switch {
+	case errors.As(err, &dnsErr):
+		return pingErrDNS, err
+	case errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) && netErr.Timeout():
+		return pingErrTimeout, err
+	case errors.As(err, &opErr) && opErr.Op == "dial",
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, io.EOF):
+		return pingErrConnect, err
+	case strings.Contains(err.Error(), "tls:") || strings.Contains(err.Error(), "x509:"):
+		return pingErrTLS, err
+	case errors.As(err, &httpErr):
+		return pingErrHTTP, fmt.Errorf("%s", httpErr.Status)
+	case errors.As(err, &rpcErr):
+		return pingErrRPC, err
+	}
+	return pingErrRPC, err
+}
+
+// pingEndpoint dials one endpoint on its own, bypassing the shared client's
+// retries and failover, and calls eth_chainId
+func pingEndpoint(ctx context.Context, rawURL string, headers http.Header) PingResult {
+	result := PingResult{URL: rawURL}
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+
+	start := time.Now()
+	var chainID hexutil.Big
+	rc, err := rpc.DialOptions(ctx, dialTarget(rawURL), rpc.WithHeaders(headers))
+	if err == nil {
+		err = rc.CallContext(ctx, &chainID, "eth_chainId")
+		rc.Close()
+	}
+	result.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+
+	if err != nil {
+		kind, detail := classifyPingError(err)
+		result.ErrorKind, result.Error = kind, detail.Error()
+		return result
+	}
+	result.OK = true
+	result.ChainID = chainID.ToInt().String()
+	return result
+}
+
+// pingEndpoints pings every --rpc endpoint in order
+func pingEndpoints(urls []string) (PingResults, error) {
+	headers, err := parseHeaders(rpcHeaders)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := commandContext()
+	defer cancel()
+
+	results := make(PingResults, len(urls))
+	for i, u := range urls {
+		results[i] = pingEndpoint(ctx, u, headers)
+	}
+	return results, nil
+}
+
+// preflight runs the --check ping before a command. It fails when no endpoint
+// responds and notes the ones that did not when others did, since the command
+// can still fall back to those.
+func preflight() error {
+	results, err := pingEndpoints(rpcURLs)
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, p := range results {
+		if !p.OK {
+			failed = append(failed, p.String())
+		}
+	}
+	if len(failed) == len(results) {
+		return fmt.Errorf("preflight check failed: %s", strings.Join(failed, "; "))
+	}
+	for _, f := range failed {
+		fmt.Fprintf(progressWriter(), "Note: endpoint unreachable, %s\n", f)
+	}
+	return nil
+}
+
+var pingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Check that the RPC endpoints are reachable",
+	Long: `Dials each --rpc endpoint on its own and calls eth_chainId, reporting the
+chain and the round-trip latency, connection included. Failures name the stage
+that failed: dns (host not found), connect (refused or reset), tls, timeout,
+http (an error status such as 401 or 429) or rpc (the node answered with an
+error). Exits non-zero if any endpoint failed.
+
+Any other command can run the same check first with --check, failing fast when
+no endpoint responds.`,
+	Annotations: map[string]string{annotationOwnConnection: ""},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		results, err := pingEndpoints(rpcURLs)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(results)
+		for _, p := range results {
+			if !p.OK {
+				exit(1)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&preflightCheck, "check", false, "Ping the RPC endpoints first and fail fast if none responds")
+
+	rootCmd.AddCommand(pingCmd)
+}
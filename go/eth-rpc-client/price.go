@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+// Default price sources: CoinGecko's simple price API for ETH and its token
+// price API for ERC-20 tokens on mainnet. {fiat} and {token} are replaced by
+// the lower-cased currency and token address.
+const (
+	defaultETHPriceURL    = "https://api.coingecko.com/api/v3/simple/price?ids=ethereum&vs_currencies={fiat}"
+	defaultETHPricePath   = "ethereum.{fiat}"
+	defaultTokenPriceURL  = "https://api.coingecko.com/api/v3/simple/token_price/ethereum?contract_addresses={token}&vs_currencies={fiat}"
+	defaultTokenPricePath = "{token}.{fiat}"
+)
+
+// priceTimeout bounds a price lookup so a slow price API cannot hold up the balance
+const priceTimeout = 10 * time.Second
+
+var (
+	fiatCurrency string
+	priceURL     string
+	pricePath    string
+
+	// priceCache holds prices fetched during this run, keyed by URL and path
+	priceCacheMu sync.Mutex
+	priceCache   = make(map[string]*big.Float)
+)
+
+// addPriceFlags registers the fiat conversion flags on a balance command
+func addPriceFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&fiatCurrency, "fiat", "", "Also show the value in this fiat currency, e.g. usd")
+	cmd.Flags().StringVar(&priceURL, "price-url", "", "Price API URL; {fiat} and {token} are substituted (default CoinGecko)")
+	cmd.Flags().StringVar(&pricePath, "price-path", "", "Dot-separated path to the price in the API's JSON response")
+}
+
+// FiatValue is a balance converted to a fiat currency
+type FiatValue struct {
+	Currency string `json:"currency"`
+	Price    string `json:"price"`
+	Value    string `json:"value"`
+}
+
+func (f FiatValue) String() string {
+	return f.Value + " " + strings.ToUpper(f.Currency)
+}
+
+// newFiatValue converts amount, a decimal string in whole units, at price
+func newFiatValue(amount string, price *big.Float) *FiatValue {
+	units, _, err := big.ParseFloat(amount, 10, 256, big.ToNearestEven)
+	if err != nil {
+		return nil
+	}
+	value := new(big.Float).SetPrec(256).Mul(units, price)
+	return &FiatValue{
+		Currency: strings.ToLower(fiatCurrency),
+		Price:    price.Text('f', -1),
+		Value:    value.Text('f', 2),
+	}
+}
+
+// priceSource returns the --price-url and --price-path with placeholders
+// filled in, falling back to the given defaults
+func priceSource(token *common.Address, defaultURL, defaultPath string) (url, path string) {
+	url, path = priceURL, pricePath
+	if url == "" {
+		url = defaultURL
+	}
+	if path == "" {
+		path = defaultPath
+	}
+
+	replacements := []string{"{fiat}", strings.ToLower(fiatCurrency)}
+	if token != nil {
+		replacements = append(replacements, "{token}", strings.ToLower(token.Hex()))
+	}
+	replacer := strings.NewReplacer(replacements...)
+	return replacer.Replace(url), replacer.Replace(path)
+}
+
+// fetchPrice returns the price at path in the JSON document served at url.
+// Prices are cached for the rest of the run.
+func fetchPrice(ctx context.Context, url, path string) (*big.Float, error) {
+	key := url + "#" + path
+	priceCacheMu.Lock()
+	defer priceCacheMu.Unlock()
+	if price, ok := priceCache[key]; ok {
+		return price, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, priceTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("price API returned %s", resp.Status)
+	}
+
+	var doc any
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid price API response: %w", err)
+	}
+
+	price, err := lookupPrice(doc, path)
+	if err != nil {
+		return nil, err
+	}
+	priceCache[key] = price
+	return price, nil
+}
+
+// printPriceNote tells the user that the fiat value is missing because the
+// price lookup failed; the balance itself is still printed
+func printPriceNote(err error) {
+	fmt.Fprintf(progressWriter(), "Note: %s price unavailable: %v\n", strings.ToUpper(fiatCurrency), err)
+}
+
+// lookupPrice follows a dot-separated path of object keys through a decoded
+// JSON document and parses the number (or numeric string) it ends at
+func lookupPrice(doc any, path string) (*big.Float, error) {
+	v := doc
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("price path %q: %q is not inside an object", path, key)
+		}
+		if v, ok = obj[key]; !ok {
+			return nil, fmt.Errorf("price path %q: key %q not found", path, key)
+		}
+	}
+
+	var s string
+	switch val := v.(type) {
+	case json.Number:
+		s = val.String()
+	case string:
+		s = val
+	default:
+		return nil, fmt.Errorf("price path %q does not point to a number", path)
+	}
+	price, _, err := big.ParseFloat(s, 10, 256, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("price path %q: invalid price %q", path, s)
+	}
+	return price, nil
+}
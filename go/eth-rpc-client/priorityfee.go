@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// PriorityFeeInfo is the result of the priority-fee command. On chains without
+// EIP-1559 EIP1559 is false and no priority fee is set.
+type PriorityFeeInfo struct {
+	EIP1559              bool   `json:"eip1559"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
+}
+
+func (p PriorityFeeInfo) renderText(w io.Writer) {
+	if !p.EIP1559 {
+		fmt.Fprintln(w, "Note: chain has no EIP-1559 fee market; priority fees do not apply")
+		return
+	}
+	printField(w, "Max Priority Fee", formatAmount(parseWei(p.MaxPriorityFeePerGas), gasPriceUnit()))
+}
+
+var priorityFeeCmd = &cobra.Command{
+	Use:   "priority-fee",
+	Short: "Show the node's suggested priority fee (miner tip)",
+	Long: `Prints the priority fee (maxPriorityFeePerGas) the node currently suggests,
+in gwei unless --unit is given. A quick check when tuning tips during
+congestion; gas-oracle shows the tips actually paid at several percentiles.
+On chains whose latest block has no base fee, priority fees do not apply and
+the command says so.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		header, err := rpcClient.GetHeaderAt(nil)
+		if err != nil {
+			fatal(err)
+		}
+		if header.BaseFee == nil {
+			render(PriorityFeeInfo{})
+			return
+		}
+
+		tip, err := rpcClient.SuggestGasTipCap()
+		if err != nil {
+			fatal(err)
+		}
+
+		render(PriorityFeeInfo{EIP1559: true, MaxPriorityFeePerGas: tip.String()})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(priorityFeeCmd)
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"golang.org/x/time/rate"
+)
+
+// rpcRate is the --rate flag: the maximum RPC requests per second, 0 for no limit
+var rpcRate float64
+
+// newLimiter returns a limiter allowing perSecond requests per second, or nil
+// when perSecond is 0. The burst is 1 so requests are evenly spaced rather
+// than sent in bursts that a provider would count against its quota.
+func newLimiter(perSecond float64) (*rate.Limiter, error) {
+	if perSecond < 0 {
+		return nil, fmt.Errorf("invalid --rate %v (must not be negative)", perSecond)
+	}
+	if perSecond == 0 {
+		return nil, nil
+	}
+	return rate.NewLimiter(rate.Limit(perSecond), 1), nil
+}
+
+// throttle blocks until the rate limiter allows another request. It returns
+// an error instead when the client's context is done first.
+func (c *Client) throttle() error {
+	if c.limiter == nil {
+		return nil
+	}
+	return c.limiter.Wait(c.ctx)
+}
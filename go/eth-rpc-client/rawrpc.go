@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/cobra"
+)
+
+// RawCall sends an arbitrary JSON-RPC request and returns its result as the
+// node sent it
+func (c *Client) RawCall(method string, params ...any) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := c.withRetry(func(ec *ethclient.Client) error {
+		return ec.Client().CallContext(c.ctx, &result, method, params...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+	return result, nil
+}
+
+// parseRPCParam parses a command-line parameter as JSON. Values that are not
+// valid JSON, such as 0x-prefixed hex or latest, are passed as strings so they
+// need no extra shell quoting.
+func parseRPCParam(s string) any {
+	var raw json.RawMessage
+	if err := json.Unmarshal([]byte(s), &raw); err != nil {
+		return s
+	}
+	return raw
+}
+
+// formatRPCError describes a JSON-RPC error response by its code, message and
+// any data the node attached, or returns the error text for other failures
+func formatRPCError(err error) string {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return err.Error()
+	}
+	msg := fmt.Sprintf("RPC error %d: %s", rpcErr.ErrorCode(), rpcErr.Error())
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) && dataErr.ErrorData() != nil {
+		data, _ := json.Marshal(dataErr.ErrorData())
+		msg += fmt.Sprintf(" (data: %s)", data)
+	}
+	return msg
+}
+
+// RawResult is the result of the rpc command: the JSON the node returned,
+// printed indented and otherwise unchanged, keeping its field order and the
+// precision of large numbers
+type RawResult json.RawMessage
+
+func (r RawResult) MarshalJSON() ([]byte, error) {
+	return json.RawMessage(r).MarshalJSON()
+}
+
+func (r RawResult) renderText(w io.Writer) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, r, "", "  "); err != nil {
+		buf.Write(r)
+	}
+	fmt.Fprintln(w, buf.String())
+}
+
+var rawRPCCmd = &cobra.Command{
+	Use:   "rpc [method] [json-params...]",
+	Short: "Send a raw JSON-RPC request",
+	Long: `Calls any JSON-RPC method and prints the result as indented JSON, for methods
+the CLI does not wrap, e.g. txpool_content or debug_traceCall. Each parameter
+is parsed as JSON, so numbers, booleans, objects and arrays can be passed;
+parameters that are not valid JSON, such as 0x1b4 or latest, are sent as
+strings. Errors returned by the node are printed with their code and message.
+
+With --template the result is decoded first, so its fields can be referenced,
+e.g. {{.number}}.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		params := make([]any, len(args)-1)
+		for i, arg := range args[1:] {
+			params[i] = parseRPCParam(arg)
+		}
+
+		result, err := rpcClient.RawCall(args[0], params...)
+		if err != nil {
+			fatal(formatRPCError(err))
+		}
+
+		if parsedTemplate != nil {
+			var value any
+			dec := json.NewDecoder(bytes.NewReader(result))
+			dec.UseNumber()
+			if err := dec.Decode(&value); err != nil {
+				fatal(err)
+			}
+			render(value)
+			return
+		}
+		render(RawResult(result))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rawRPCCmd)
+}
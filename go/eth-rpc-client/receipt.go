@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// GetReceipt returns the receipt of a mined transaction. Receipts from
+// finalized blocks are cached when --cache-dir is set.
+func (c *Client) GetReceipt(hash string) (*types.Receipt, error) {
+	txHash, err := parseHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	fetch := func() (*types.Receipt, error) {
+		var receipt *types.Receipt
+		err := c.withRetry(func(ec *ethclient.Client) (err error) {
+			receipt, err = ec.TransactionReceipt(c.ctx, txHash)
+			return err
+		})
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("receipt for %s not found (transaction unknown or still pending)", hash)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receipt: %w", err)
+		}
+		return receipt, nil
+	}
+
+	if c.cache != nil {
+		return c.cachedReceipt(txHash.Hex(), fetch)
+	}
+	return fetch()
+}
+
+// receiptStatus maps a receipt status code to "success" or "failed"
+func receiptStatus(status uint64) string {
+	if status == types.ReceiptStatusSuccessful {
+		return "success"
+	}
+	return "failed"
+}
+
+// ReceiptInfo is the result of the receipt command
+type ReceiptInfo struct {
+	TxHash            string `json:"transactionHash"`
+	Status            string `json:"status"`
+	BlockNumber       uint64 `json:"blockNumber"`
+	TransactionIndex  uint   `json:"transactionIndex"`
+	GasUsed           uint64 `json:"gasUsed"`
+	CumulativeGasUsed uint64 `json:"cumulativeGasUsed"`
+	EffectiveGasPrice string `json:"effectiveGasPrice,omitempty"`
+	ContractAddress   string `json:"contractAddress,omitempty"`
+	Logs              int    `json:"logs"`
+
+	// Events lists every log when --abi is given, decoded where it matched
+	Events []LogEvent `json:"events,omitempty"`
+}
+
+// newReceiptInfo builds the printable form of a receipt
+func newReceiptInfo(receipt *types.Receipt) ReceiptInfo {
+	info := ReceiptInfo{
+		TxHash:            receipt.TxHash.Hex(),
+		Status:            receiptStatus(receipt.Status),
+		BlockNumber:       receipt.BlockNumber.Uint64(),
+		TransactionIndex:  receipt.TransactionIndex,
+		GasUsed:           receipt.GasUsed,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		Logs:              len(receipt.Logs),
+	}
+	if receipt.EffectiveGasPrice != nil {
+		info.EffectiveGasPrice = receipt.EffectiveGasPrice.String()
+	}
+	if receipt.ContractAddress != (common.Address{}) {
+		info.ContractAddress = receipt.ContractAddress.Hex()
+	}
+	return info
+}
+
+// statusString colors a receipt status green for success and red for failure
+func statusString(status string) string {
+	if status == "success" {
+		return green(status)
+	}
+	return red(status)
+}
+
+func (r ReceiptInfo) renderText(w io.Writer) {
+	fmt.Fprintf(w, "\n%s\n\n", cyan("Receipt "+r.TxHash))
+	fmt.Fprintf(w, "%s %s\n", cyan("Status:"), statusString(r.Status))
+	printField(w, "Block", r.BlockNumber)
+	printField(w, "Transaction Index", r.TransactionIndex)
+	printField(w, "Gas Used", r.GasUsed)
+	printField(w, "Cumulative Gas Used", r.CumulativeGasUsed)
+	if r.EffectiveGasPrice != "" {
+		printField(w, "Effective Gas Price", formatAmount(parseWei(r.EffectiveGasPrice), gasPriceUnit()))
+	}
+	if r.ContractAddress != "" {
+		printField(w, "Contract Address", r.ContractAddress)
+	}
+	printField(w, "Logs", r.Logs)
+	for _, event := range r.Events {
+		event.renderText(w)
+	}
+}
+
+var receiptCmd = &cobra.Command{
+	Use:   "receipt [hash]",
+	Short: "Get transaction receipt",
+	Long: `Shows a transaction receipt. With --abi every log is listed as well, and logs
+whose first topic matches an event in the ABI are decoded into named arguments;
+the rest are printed as raw topics and data.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		receipt, err := rpcClient.GetReceipt(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		info := newReceiptInfo(receipt)
+		if abiRequested() {
+			decode, err := rpcClient.logDecoder()
+			if err != nil {
+				fatal(err)
+			}
+			for _, l := range receipt.Logs {
+				event := newLogEvent(*l)
+				event.Decoded = decode(l)
+				info.Events = append(info.Events, event)
+			}
+		}
+
+		render(info)
+	},
+}
+
+func init() {
+	addABIFlags(receiptCmd, "Decode the receipt's logs with the events in this JSON ABI, as a file or inline JSON")
+
+	rootCmd.AddCommand(receiptCmd)
+}
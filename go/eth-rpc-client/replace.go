@@ -0,0 +1,265 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var (
+	replaceBumpPercent uint64
+	replaceWait        bool
+)
+
+// minReplacementBump is the smallest fee increase, in percent, geth and most
+// other clients accept for a transaction replacing one in their pool
+const minReplacementBump = 10
+
+// bumpFee raises fee by percent, rounding up so that the increase is never
+// short of the percentage
+func bumpFee(fee *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// bigMax returns the larger of a and b
+func bigMax(a, b *big.Int) *big.Int {
+	if a.Cmp(b) < 0 {
+		return b
+	}
+	return a
+}
+
+// ReplaceTransaction rebroadcasts a pending transaction from the key's address
+// with the same nonce, recipient, value, data and gas limit, its fees raised
+// by bumpPercent or to the node's current suggestion if that is higher. It
+// returns the original and the replacement.
+func (c *Client) ReplaceTransaction(priv *ecdsa.PrivateKey, hash common.Hash, bumpPercent uint64) (original, replacement *types.Transaction, err error) {
+	original, pending, err := c.GetTransaction(hash.Hex())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !pending {
+		return nil, nil, fmt.Errorf("transaction %s is already mined; there is nothing to replace", hash.Hex())
+	}
+
+	chainID, err := c.GetChainID()
+	if err != nil {
+		return nil, nil, err
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	sender, err := types.Sender(signer, original)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to recover sender: %w", err)
+	}
+	if from := crypto.PubkeyToAddress(priv.PublicKey); sender != from {
+		return nil, nil, fmt.Errorf("transaction %s was sent by %s, not by the signing key's address %s", hash.Hex(), sender.Hex(), from.Hex())
+	}
+
+	fees, err := c.EstimateFees()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var inner types.TxData
+	switch original.Type() {
+	case types.LegacyTxType:
+		inner = &types.LegacyTx{
+			Nonce:    original.Nonce(),
+			GasPrice: bigMax(bumpFee(original.GasPrice(), bumpPercent), fees.GasPrice),
+			Gas:      original.Gas(),
+			To:       original.To(),
+			Value:    original.Value(),
+			Data:     original.Data(),
+		}
+	case types.AccessListTxType:
+		inner = &types.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      original.Nonce(),
+			GasPrice:   bigMax(bumpFee(original.GasPrice(), bumpPercent), fees.GasPrice),
+			Gas:        original.Gas(),
+			To:         original.To(),
+			Value:      original.Value(),
+			Data:       original.Data(),
+			AccessList: original.AccessList(),
+		}
+	case types.DynamicFeeTxType:
+		tip := bumpFee(original.GasTipCap(), bumpPercent)
+		feeCap := bumpFee(original.GasFeeCap(), bumpPercent)
+		if fees.MaxFeePerGas != nil {
+			tip = bigMax(tip, fees.TipCap)
+			feeCap = bigMax(feeCap, fees.MaxFeePerGas)
+		}
+		inner = &types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      original.Nonce(),
+			GasTipCap:  tip,
+			GasFeeCap:  bigMax(feeCap, tip),
+			Gas:        original.Gas(),
+			To:         original.To(),
+			Value:      original.Value(),
+			Data:       original.Data(),
+			AccessList: original.AccessList(),
+		}
+	default:
+		return nil, nil, fmt.Errorf("cannot replace type %d transactions", original.Type())
+	}
+
+	replacement, err = types.SignTx(types.NewTx(inner), signer, priv)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	if err := c.broadcast(replacement); err != nil {
+		return nil, nil, fmt.Errorf("failed to send replacement: %w", err)
+	}
+	return original, replacement, nil
+}
+
+// WaitReplaced waits for the replacement to be mined. The original can still
+// win the race for the nonce, in which case the replacement is dropped and the
+// original's receipt is returned instead.
+func (c *Client) WaitReplaced(original, replacement *types.Transaction) (*types.Receipt, error) {
+	receipt, err := c.WaitMined(replacement.Hash(), 0)
+	if !errors.Is(err, errTxDropped) && !errors.Is(err, errTxUnknown) {
+		return receipt, err
+	}
+
+	var originalReceipt *types.Receipt
+	lookupErr := c.withRetry(func(ec *ethclient.Client) (err error) {
+		originalReceipt, err = ec.TransactionReceipt(c.ctx, original.Hash())
+		return err
+	})
+	if errors.Is(lookupErr, ethereum.NotFound) {
+		return nil, err
+	}
+	if lookupErr != nil {
+		return nil, fmt.Errorf("failed to get receipt: %w", lookupErr)
+	}
+	return originalReceipt, nil
+}
+
+// ReplaceResult is the result of the replace command. Fees are in wei: gas
+// prices for legacy transactions, priority and max fees for EIP-1559 ones.
+type ReplaceResult struct {
+	Original                     string `json:"original"`
+	Hash                         string `json:"hash"`
+	From                         string `json:"from"`
+	Nonce                        uint64 `json:"nonce"`
+	OriginalGasPrice             string `json:"originalGasPrice,omitempty"`
+	GasPrice                     string `json:"gasPrice,omitempty"`
+	OriginalMaxPriorityFeePerGas string `json:"originalMaxPriorityFeePerGas,omitempty"`
+	MaxPriorityFeePerGas         string `json:"maxPriorityFeePerGas,omitempty"`
+	OriginalMaxFeePerGas         string `json:"originalMaxFeePerGas,omitempty"`
+	MaxFeePerGas                 string `json:"maxFeePerGas,omitempty"`
+	Status                       string `json:"status,omitempty"`
+	BlockNumber                  uint64 `json:"blockNumber,omitempty"`
+	Mined                        string `json:"mined,omitempty"`
+}
+
+func (r ReplaceResult) renderText(w io.Writer) {
+	unit := gasPriceUnit()
+	change := func(from, to string) string {
+		return fmt.Sprintf("%s -> %s", formatAmount(parseWei(from), unit), formatAmount(parseWei(to), unit))
+	}
+
+	printField(w, "Original", r.Original)
+	printField(w, "Replacement", r.Hash)
+	printField(w, "Nonce", r.Nonce)
+	if r.GasPrice != "" {
+		printField(w, "Gas Price", change(r.OriginalGasPrice, r.GasPrice))
+	} else {
+		printField(w, "Max Priority Fee", change(r.OriginalMaxPriorityFeePerGas, r.MaxPriorityFeePerGas))
+		printField(w, "Max Fee", change(r.OriginalMaxFeePerGas, r.MaxFeePerGas))
+	}
+	if r.Status != "" {
+		if r.Mined == r.Original {
+			printField(w, "Mined", "original transaction (the replacement was dropped)")
+		}
+		fmt.Fprintf(w, "%s %s\n", cyan("Status:"), statusString(r.Status))
+		printField(w, "Block", r.BlockNumber)
+	}
+}
+
+var replaceCmd = &cobra.Command{
+	Use:   "replace [hash]",
+	Short: "Speed up a pending transaction by resending it with higher fees",
+	Long: `Replaces a stuck pending transaction with a copy that has the same nonce,
+recipient, value, data and gas limit but fees raised by --bump-percent
+(default 15%), or to the node's current suggestion when that is higher, signed
+with the given key. Once either is mined the other becomes invalid; with
+--wait, the result says which one was.
+
+Nodes only accept a replacement that raises the fees by at least 10%. The
+command refuses transactions that are already mined and transactions that were
+not sent by the signing key's address.`,
+	Annotations: map[string]string{annotationLongRunning: "wait"},
+	Args:        cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		hash, err := parseHash(args[0])
+		if err != nil {
+			fatal(err)
+		}
+		if replaceBumpPercent < minReplacementBump {
+			fatalf("invalid --bump-percent %d (nodes reject replacements that raise fees by less than %d%%)", replaceBumpPercent, minReplacementBump)
+		}
+
+		priv, err := loadSigningKey(cmd)
+		if err != nil {
+			fatal(err)
+		}
+		defer zeroKey(priv)
+
+		original, replacement, err := rpcClient.ReplaceTransaction(priv, hash, replaceBumpPercent)
+		if err != nil {
+			fatal(err)
+		}
+
+		result := ReplaceResult{
+			Original: original.Hash().Hex(),
+			Hash:     replacement.Hash().Hex(),
+			From:     crypto.PubkeyToAddress(priv.PublicKey).Hex(),
+			Nonce:    replacement.Nonce(),
+		}
+		if replacement.Type() == types.DynamicFeeTxType {
+			result.OriginalMaxPriorityFeePerGas = original.GasTipCap().String()
+			result.MaxPriorityFeePerGas = replacement.GasTipCap().String()
+			result.OriginalMaxFeePerGas = original.GasFeeCap().String()
+			result.MaxFeePerGas = replacement.GasFeeCap().String()
+		} else {
+			result.OriginalGasPrice = original.GasPrice().String()
+			result.GasPrice = replacement.GasPrice().String()
+		}
+
+		if replaceWait {
+			fmt.Fprintf(progressWriter(), "Waiting for %s to be mined...\n", replacement.Hash().Hex())
+			receipt, err := rpcClient.WaitReplaced(original, replacement)
+			if err != nil {
+				fatal(err)
+			}
+			result.Status = receiptStatus(receipt.Status)
+			result.BlockNumber = receipt.BlockNumber.Uint64()
+			result.Mined = receipt.TxHash.Hex()
+		}
+
+		render(result)
+	},
+}
+
+func init() {
+	replaceCmd.Flags().Uint64Var(&replaceBumpPercent, "bump-percent", 15, "Percentage to raise the gas price or priority and max fees by")
+	replaceCmd.Flags().BoolVar(&replaceWait, "wait", false, "Wait for the replacement to be mined")
+	addKeyFlags(replaceCmd)
+
+	rootCmd.AddCommand(replaceCmd)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	rpcRetries    int
+	rpcRetryDelay time.Duration
+)
+
+// withRetry runs an RPC call, retrying transient failures with exponential
+// backoff up to c.retries times. Deterministic failures such as JSON-RPC
+// errors ("execution reverted") are returned immediately, and retrying stops
+// as soon as the client's context is done.
+func (c *Client) withRetry(call func(ec *ethclient.Client) error) error {
+	delay := c.retryDelay
+	for attempt := 0; ; attempt++ {
+		err := c.withFailover(call)
+		if err == nil || attempt >= c.retries || !isTransient(err) {
+			return err
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// withFailover runs an RPC call against each endpoint in order until one
+// succeeds or fails with a non-transient error. Every call starts again from
+// the primary endpoint, so it is used again as soon as it recovers, except
+// that endpoints whose circuit breaker is open are skipped. Each attempt
+// waits for the --rate limiter first.
+func (c *Client) withFailover(call func(ec *ethclient.Client) error) error {
+	err := errCircuitOpen
+	for i, endpoint := range c.endpoints {
+		breaker := c.breakers[i]
+		if !breaker.allow() {
+			continue
+		}
+		if err := c.throttle(); err != nil {
+			return err
+		}
+		err = call(endpoint)
+		if c.ctx.Err() != nil {
+			return err
+		}
+		breaker.record(err)
+		if err == nil || !isTransient(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// isTransient reports whether an RPC error is worth retrying: connection
+// failures, timeouts of individual requests and HTTP 429/502/503/504 responses
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+// defaultSelectorLookupURL is the 4byte directory's signature search;
+// {selector} is replaced by the 0x-prefixed selector
+const defaultSelectorLookupURL = "https://www.4byte.directory/api/v1/signatures/?hex_signature={selector}"
+
+// selectorLookupTimeout bounds a signature directory lookup
+const selectorLookupTimeout = 10 * time.Second
+
+var (
+	selectorLookup    bool
+	selectorLookupURL string
+)
+
+// splitParams splits a parameter list at commas outside parentheses
+func splitParams(s string) []string {
+	var params []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				params = append(params, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(params, s[start:])
+}
+
+// closingParen returns the index of the parenthesis closing the one at open,
+// or -1 if it is unbalanced
+func closingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// canonicalParams reduces a parameter list to its types, dropping names and
+// data locations and expanding the uint and int aliases
+func canonicalParams(s string) (string, error) {
+	if strings.TrimSpace(s) == "" {
+		return "", nil
+	}
+	var types []string
+	for _, param := range splitParams(s) {
+		param = strings.TrimPrefix(strings.TrimSpace(param), "tuple")
+		if strings.HasPrefix(param, "(") {
+			end := closingParen(param, 0)
+			if end < 0 {
+				return "", fmt.Errorf("unbalanced parentheses in %q", param)
+			}
+			inner, err := canonicalParams(param[1:end])
+			if err != nil {
+				return "", err
+			}
+			suffix := ""
+			if rest := strings.TrimSpace(param[end+1:]); strings.HasPrefix(rest, "[") {
+				suffix = strings.Fields(rest)[0]
+			}
+			types = append(types, "("+inner+")"+suffix)
+			continue
+		}
+
+		fields := strings.Fields(param)
+		if len(fields) == 0 {
+			return "", fmt.Errorf("empty parameter")
+		}
+		typ := fields[0]
+		base, dims, _ := strings.Cut(typ, "[")
+		if dims != "" {
+			dims = "[" + dims
+		}
+		switch base {
+		case "uint", "int":
+			base += "256"
+		}
+		if _, err := abi.NewType(base+dims, "", nil); err != nil {
+			return "", fmt.Errorf("invalid type %q", typ)
+		}
+		types = append(types, base+dims)
+	}
+	return strings.Join(types, ","), nil
+}
+
+// canonicalSignature returns the canonical form of a function signature, e.g.
+// "transfer(address,uint256)" for "function transfer(address to, uint amount)
+// external returns (bool)"
+func canonicalSignature(sig string) (string, error) {
+	s := strings.TrimSpace(sig)
+	s = strings.TrimSpace(strings.TrimPrefix(s, "function "))
+	open := strings.IndexByte(s, '(')
+	if open <= 0 {
+		return "", fmt.Errorf("invalid signature %q (expected name(type,...), e.g. transfer(address,uint256))", sig)
+	}
+	name := strings.TrimSpace(s[:open])
+	for i, r := range name {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9') {
+			return "", fmt.Errorf("invalid function name %q in signature %q", name, sig)
+		}
+	}
+	end := closingParen(s, open)
+	if end < 0 {
+		return "", fmt.Errorf("invalid signature %q: unbalanced parentheses", sig)
+	}
+	params, err := canonicalParams(s[open+1 : end])
+	if err != nil {
+		return "", fmt.Errorf("invalid signature %q: %w", sig, err)
+	}
+	return name + "(" + params + ")", nil
+}
+
+// SelectorInfo is the result of the selector command: the first 4 bytes of
+// the keccak256 hash of the canonical signature, and the full hash
+type SelectorInfo struct {
+	Signature string `json:"signature"`
+	Selector  string `json:"selector"`
+	MethodID  string `json:"methodId"`
+}
+
+func (s SelectorInfo) renderText(w io.Writer) {
+	printField(w, "Signature", s.Signature)
+	printField(w, "Selector", s.Selector)
+	printField(w, "Method ID", s.MethodID)
+}
+
+// SelectorLookup is the result of the selector command with --lookup.
+// Signatures sharing a selector are listed oldest first, which is usually the
+// intended one.
+type SelectorLookup struct {
+	Selector   string   `json:"selector"`
+	Signatures []string `json:"signatures"`
+}
+
+func (s SelectorLookup) renderText(w io.Writer) {
+	printField(w, "Selector", s.Selector)
+	if len(s.Signatures) == 0 {
+		fmt.Fprintln(w, "No known signatures")
+		return
+	}
+	for _, sig := range s.Signatures {
+		fmt.Fprintf(w, "  %s\n", sig)
+	}
+}
+
+// lookupSelector queries a 4byte-style signature directory for the text
+// signatures registered for a selector
+func lookupSelector(ctx context.Context, rawURL, selector string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, selectorLookupTimeout)
+	defer cancel()
+
+	target := strings.ReplaceAll(rawURL, "{selector}", url.QueryEscape(selector))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("signature lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("signature directory returned %s", resp.Status)
+	}
+
+	var page struct {
+		Results []struct {
+			ID            uint64 `json:"id"`
+			TextSignature string `json:"text_signature"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("invalid signature directory response: %w", err)
+	}
+	sort.SliceStable(page.Results, func(i, j int) bool { return page.Results[i].ID < page.Results[j].ID })
+
+	signatures := make([]string, 0, len(page.Results))
+	for _, r := range page.Results {
+		signatures = append(signatures, r.TextSignature)
+	}
+	return signatures, nil
+}
+
+var selectorCmd = &cobra.Command{
+	Use:   "selector [signature | selector]",
+	Short: "Compute a function selector, or look up the signatures for one",
+	Long: `Prints the 4-byte selector of a function signature, the first 4 bytes of the
+keccak256 hash of its canonical form, and the full hash. Parameter names, data
+locations and the uint/int aliases are normalized, so a Solidity declaration
+can be pasted as is:
+
+  eth-rpc selector "transfer(address,uint256)"
+  eth-rpc selector "function transfer(address to, uint amount) external"
+
+With --lookup the argument is a selector, or calldata whose first 4 bytes are
+used, and the matching signatures are fetched from a 4byte-style directory
+(--lookup-url, default www.4byte.directory). Different signatures can share a
+selector; they are listed oldest first.`,
+	Annotations: map[string]string{annotationOffline: ""},
+	Args:        cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !selectorLookup {
+			sig, err := canonicalSignature(args[0])
+			if err != nil {
+				if strings.HasPrefix(args[0], "0x") {
+					err = fmt.Errorf("%w; use --lookup to find the signatures for a selector", err)
+				}
+				fatal(err)
+			}
+			hash := crypto.Keccak256([]byte(sig))
+			render(SelectorInfo{
+				Signature: sig,
+				Selector:  hexutil.Encode(hash[:4]),
+				MethodID:  hexutil.Encode(hash),
+			})
+			return
+		}
+
+		data, err := hexutil.Decode(args[0])
+		if err != nil || len(data) < 4 {
+			fatalf("invalid selector %q (expected 0x followed by at least 8 hex characters)", args[0])
+		}
+		selector := hexutil.Encode(data[:4])
+
+		signatures, err := lookupSelector(context.Background(), selectorLookupURL, selector)
+		if err != nil {
+			fatal(err)
+		}
+		render(SelectorLookup{Selector: selector, Signatures: signatures})
+	},
+}
+
+func init() {
+	selectorCmd.Flags().BoolVar(&selectorLookup, "lookup", false, "Look up the signatures registered for a selector or calldata")
+	selectorCmd.Flags().StringVar(&selectorLookupURL, "lookup-url", defaultSelectorLookupURL, "4byte-style signature directory URL; {selector} is substituted")
+
+	rootCmd.AddCommand(selectorCmd)
+}
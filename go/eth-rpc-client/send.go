@@ -0,0 +1,209 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var (
+	sendTo     string
+	sendAmount string
+	sendWait   bool
+	sendAccess string
+)
+
+// SendETH signs and broadcasts a dynamic-fee ETH transfer from the key's
+// address, optionally carrying an EIP-2930 access list
+func (c *Client) SendETH(priv *ecdsa.PrivateKey, to string, amountWei *big.Int, accessList types.AccessList) (common.Hash, error) {
+	toAddr, err := c.Resolve(to)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	signed, err := c.sendDynamicFeeTx(priv, &toAddr, amountWei, nil, 0, accessList)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return signed.Hash(), nil
+}
+
+// sendDynamicFeeTx signs and broadcasts an EIP-1559 transaction from the
+// key's address and returns it. A nil to creates a contract, and a zero gas
+// limit is estimated.
+func (c *Client) sendDynamicFeeTx(priv *ecdsa.PrivateKey, to *common.Address, value *big.Int, data []byte, gas uint64, accessList types.AccessList) (*types.Transaction, error) {
+	from := crypto.PubkeyToAddress(priv.PublicKey)
+
+	var nonce uint64
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		nonce, err = ec.PendingNonceAt(c.ctx, from)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	if gas == 0 {
+		err = c.withRetry(func(ec *ethclient.Client) (err error) {
+			gas, err = ec.EstimateGas(c.ctx, ethereum.CallMsg{From: from, To: to, Value: value, Data: data, AccessList: accessList})
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", wrapRevert(err))
+		}
+	}
+
+	fees, err := c.EstimateFees()
+	if err != nil {
+		return nil, err
+	}
+	if fees.BaseFee == nil {
+		return nil, fmt.Errorf("chain does not support EIP-1559 dynamic fee transactions")
+	}
+
+	chainID, err := c.GetChainID()
+	if err != nil {
+		return nil, err
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:    chainID,
+		Nonce:      nonce,
+		GasTipCap:  fees.TipCap,
+		GasFeeCap:  fees.MaxFeePerGas,
+		Gas:        gas,
+		To:         to,
+		Value:      value,
+		Data:       data,
+		AccessList: accessList,
+	})
+
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), priv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	if err := c.broadcast(signed); err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+	return signed, nil
+}
+
+// broadcast sends a signed transaction, failing over to the next endpoint
+// only on transient errors. Rejections such as "nonce too low" or
+// "replacement transaction underpriced" are returned as they are, and the
+// send is never retried on the same endpoint. An endpoint that fails
+// transiently may still have relayed the transaction, so a fallback that
+// already knows it counts as a success.
+func (c *Client) broadcast(tx *types.Transaction) error {
+	attempt := 0
+	return c.withFailover(func(ec *ethclient.Client) error {
+		attempt++
+		err := ec.SendTransaction(c.ctx, tx)
+		if err != nil && attempt > 1 && isAlreadyKnown(err) {
+			return nil
+		}
+		return err
+	})
+}
+
+// isAlreadyKnown reports whether a node rejected a transaction because it is
+// already in its pool. Geth says "already known", older clients "known
+// transaction".
+func isAlreadyKnown(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "known transaction")
+}
+
+// SendResult is the result of the send command
+type SendResult struct {
+	Hash        string `json:"hash"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Value       string `json:"value"`
+	Status      string `json:"status,omitempty"`
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+}
+
+func (r SendResult) renderText(w io.Writer) {
+	printField(w, "Transaction", r.Hash)
+	if r.Status != "" {
+		fmt.Fprintf(w, "%s %s\n", cyan("Status:"), statusString(r.Status))
+		printField(w, "Block", r.BlockNumber)
+	}
+}
+
+var sendCmd = &cobra.Command{
+	Use:         "send",
+	Short:       "Send ETH to an address",
+	Annotations: map[string]string{annotationLongRunning: "wait"},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		amount, err := parseUnits(sendAmount, 18)
+		if err != nil {
+			fatal(err)
+		}
+
+		priv, err := loadSigningKey(cmd)
+		if err != nil {
+			fatal(err)
+		}
+		defer zeroKey(priv)
+
+		to, err := rpcClient.Resolve(sendTo)
+		if err != nil {
+			fatal(err)
+		}
+
+		var accessList types.AccessList
+		if sendAccess != "" {
+			if accessList, err = loadAccessList(sendAccess); err != nil {
+				fatal(err)
+			}
+		}
+
+		hash, err := rpcClient.SendETH(priv, to.Hex(), amount, accessList)
+		if err != nil {
+			fatal(err)
+		}
+
+		result := SendResult{
+			Hash:  hash.Hex(),
+			From:  crypto.PubkeyToAddress(priv.PublicKey).Hex(),
+			To:    to.Hex(),
+			Value: amount.String(),
+		}
+
+		if sendWait {
+			fmt.Fprintf(progressWriter(), "Waiting for %s to be mined...\n", hash.Hex())
+			receipt, err := rpcClient.WaitMined(hash, 0)
+			if err != nil {
+				fatal(err)
+			}
+			result.Status = receiptStatus(receipt.Status)
+			result.BlockNumber = receipt.BlockNumber.Uint64()
+		}
+
+		render(result)
+	},
+}
+
+func init() {
+	sendCmd.Flags().StringVar(&sendTo, "to", "", "Recipient address or ENS name")
+	sendCmd.Flags().StringVar(&sendAmount, "amount", "", "Amount to send in ETH")
+	sendCmd.Flags().BoolVar(&sendWait, "wait", false, "Wait for the transaction to be mined")
+	sendCmd.Flags().StringVar(&sendAccess, "access-list", "", "JSON access list file, e.g. the output of access-list -o json")
+	addKeyFlags(sendCmd)
+	sendCmd.MarkFlagRequired("to")
+	sendCmd.MarkFlagRequired("amount")
+
+	rootCmd.AddCommand(sendCmd)
+}
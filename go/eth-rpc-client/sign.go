@@ -0,0 +1,172 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	signMessage string
+
+	verifyAddress   string
+	verifyMessage   string
+	verifySignature string
+)
+
+// SignMessage signs msg with the EIP-191 personal message prefix
+// ("\x19Ethereum Signed Message:\n" + length), as personal_sign does. The
+// 65-byte signature uses a recovery id of 27 or 28 in its final byte so it
+// verifies in wallets and dApps.
+func SignMessage(priv *ecdsa.PrivateKey, msg []byte) ([]byte, error) {
+	sig, err := crypto.Sign(accounts.TextHash(msg), priv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+// RecoverSigner returns the address that signed msg with an EIP-191 personal
+// message signature. Both the 27/28 and 0/1 recovery id conventions are accepted.
+func RecoverSigner(msg, sig []byte) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("signature must be %d bytes, got %d", crypto.SignatureLength, len(sig))
+	}
+
+	normalized := make([]byte, len(sig))
+	copy(normalized, sig)
+	if v := normalized[crypto.RecoveryIDOffset]; v == 27 || v == 28 {
+		normalized[crypto.RecoveryIDOffset] -= 27
+	}
+	if normalized[crypto.RecoveryIDOffset] > 1 {
+		return common.Address{}, fmt.Errorf("invalid signature recovery id %d", sig[crypto.RecoveryIDOffset])
+	}
+
+	pub, err := crypto.SigToPub(accounts.TextHash(msg), normalized)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// VerifyMessage reports whether sig is a valid EIP-191 personal message
+// signature of msg by addr
+func VerifyMessage(addr common.Address, msg, sig []byte) (bool, error) {
+	signer, err := RecoverSigner(msg, sig)
+	if err != nil {
+		return false, err
+	}
+	return signer == addr, nil
+}
+
+// SignatureInfo is the result of the sign command
+type SignatureInfo struct {
+	Address   string `json:"address"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+}
+
+func (s SignatureInfo) renderText(w io.Writer) {
+	printField(w, "Address", s.Address)
+	printField(w, "Signature", s.Signature)
+}
+
+var signCmd = &cobra.Command{
+	Use:         "sign",
+	Short:       "Sign a message (EIP-191 personal_sign)",
+	Annotations: map[string]string{annotationOffline: ""},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		priv, err := loadSigningKey(cmd)
+		if err != nil {
+			fatal(err)
+		}
+		defer zeroKey(priv)
+
+		sig, err := SignMessage(priv, []byte(signMessage))
+		if err != nil {
+			fatal(err)
+		}
+
+		render(SignatureInfo{
+			Address:   crypto.PubkeyToAddress(priv.PublicKey).Hex(),
+			Message:   signMessage,
+			Signature: hexutil.Encode(sig),
+		})
+	},
+}
+
+// VerifyResult is the result of the verify command
+type VerifyResult struct {
+	Valid     bool   `json:"valid"`
+	Address   string `json:"address"`
+	Recovered string `json:"recovered"`
+}
+
+func (v VerifyResult) renderText(w io.Writer) {
+	if v.Valid {
+		fmt.Fprintf(w, "%s %s\n", cyan("Signature:"), green("valid"))
+	} else {
+		fmt.Fprintf(w, "%s %s\n", cyan("Signature:"), red("invalid"))
+	}
+	printField(w, "Recovered Address", v.Recovered)
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verify an EIP-191 (personal_sign) message signature",
+	Long: `Recovers the signer of a personal_sign signature and checks it against
+--address. Exits non-zero when the signature is not from that address.`,
+	Annotations: map[string]string{annotationOffline: ""},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !common.IsHexAddress(verifyAddress) {
+			fatalf("invalid address %q", verifyAddress)
+		}
+		addr := common.HexToAddress(verifyAddress)
+
+		sig, err := hexutil.Decode(verifySignature)
+		if err != nil {
+			fatalf("invalid signature: %v", err)
+		}
+
+		signer, err := RecoverSigner([]byte(verifyMessage), sig)
+		if err != nil {
+			fatal(err)
+		}
+
+		result := VerifyResult{
+			Valid:     signer == addr,
+			Address:   addr.Hex(),
+			Recovered: signer.Hex(),
+		}
+
+		render(result)
+		if !result.Valid {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	signCmd.Flags().StringVar(&signMessage, "message", "", "Message to sign")
+	addKeyFlags(signCmd)
+	signCmd.MarkFlagRequired("message")
+
+	verifyCmd.Flags().StringVar(&verifyAddress, "address", "", "Address the signature is claimed to be from")
+	verifyCmd.Flags().StringVar(&verifyMessage, "message", "", "Message that was signed")
+	verifyCmd.Flags().StringVar(&verifySignature, "signature", "", "65-byte hex signature")
+	verifyCmd.MarkFlagRequired("address")
+	verifyCmd.MarkFlagRequired("message")
+	verifyCmd.MarkFlagRequired("signature")
+
+	rootCmd.AddCommand(signCmd)
+	rootCmd.AddCommand(verifyCmd)
+}
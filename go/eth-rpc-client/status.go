@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// SyncProgress returns the node's sync progress, or nil when it is fully synced
+func (c *Client) SyncProgress() (*ethereum.SyncProgress, error) {
+	var progress *ethereum.SyncProgress
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		progress, err = ec.SyncProgress(c.ctx)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync progress: %w", err)
+	}
+	return progress, nil
+}
+
+// GetClientVersion returns the node's web3_clientVersion string
+func (c *Client) GetClientVersion() (string, error) {
+	var version string
+	err := c.withRetry(func(ec *ethclient.Client) error {
+		return ec.Client().CallContext(c.ctx, &version, "web3_clientVersion")
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get client version: %w", err)
+	}
+	return version, nil
+}
+
+// NodeStatus is the result of the status command. Fields the node does not
+// expose are left empty.
+type NodeStatus struct {
+	ClientVersion string  `json:"clientVersion,omitempty"`
+	Syncing       bool    `json:"syncing"`
+	CurrentBlock  uint64  `json:"currentBlock,omitempty"`
+	HighestBlock  uint64  `json:"highestBlock,omitempty"`
+	Percent       float64 `json:"percent,omitempty"`
+	Peers         *uint64 `json:"peers,omitempty"`
+}
+
+func (s NodeStatus) renderText(w io.Writer) {
+	if s.ClientVersion != "" {
+		printField(w, "Client", s.ClientVersion)
+	}
+	if s.Syncing {
+		printField(w, "Sync", fmt.Sprintf("syncing, block %d of %d (%.2f%%)", s.CurrentBlock, s.HighestBlock, s.Percent))
+	} else {
+		printField(w, "Sync", "fully synced")
+	}
+	if s.Peers != nil {
+		printField(w, "Peers", *s.Peers)
+	} else {
+		printField(w, "Peers", "unavailable")
+	}
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show node sync status, peer count and client version",
+	Long: `Reports the node's client version, whether it is syncing (with current and
+highest block and percent complete) and its peer count. Public gateways often
+hide the client version or peer count; those are then omitted.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		progress, err := rpcClient.SyncProgress()
+		if err != nil {
+			fatal(err)
+		}
+
+		var status NodeStatus
+		if progress != nil {
+			status.Syncing = true
+			status.CurrentBlock = progress.CurrentBlock
+			status.HighestBlock = progress.HighestBlock
+			if progress.HighestBlock > 0 {
+				status.Percent = float64(progress.CurrentBlock) / float64(progress.HighestBlock) * 100
+			}
+		}
+		if version, err := rpcClient.GetClientVersion(); err == nil {
+			status.ClientVersion = version
+		}
+		if peers, err := rpcClient.GetPeerCount(); err == nil {
+			status.Peers = &peers
+		}
+
+		render(status)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var storageBlock string
+
+// GetStorageAt returns the raw 32-byte value of a contract storage slot at
+// the given block, or the latest block when block is nil
+func (c *Client) GetStorageAt(addr string, slot common.Hash, block *big.Int) ([]byte, error) {
+	account, err := c.Resolve(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var value []byte
+	err = c.withRetry(func(ec *ethclient.Client) (err error) {
+		value, err = ec.StorageAt(c.ctx, account, slot, block)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage: %w", err)
+	}
+	return value, nil
+}
+
+// parseSlot parses a storage slot given in decimal or 0x-prefixed hex
+func parseSlot(s string) (common.Hash, error) {
+	digits, base := s, 10
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		digits, base = s[2:], 16
+	}
+
+	n, ok := new(big.Int).SetString(digits, base)
+	if !ok || n.Sign() < 0 || n.BitLen() > 256 {
+		return common.Hash{}, fmt.Errorf("invalid slot %q", s)
+	}
+	return common.BigToHash(n), nil
+}
+
+// StorageInfo is the result of the storage command
+type StorageInfo struct {
+	Address string `json:"address"`
+	Slot    string `json:"slot"`
+	Value   string `json:"value"`
+}
+
+func (s StorageInfo) renderText(w io.Writer) {
+	printField(w, "Slot", s.Slot)
+	printField(w, "Value", s.Value)
+}
+
+var storageCmd = &cobra.Command{
+	Use:   "storage [address] [slot]",
+	Short: "Read a contract storage slot",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		slot, err := parseSlot(args[1])
+		if err != nil {
+			fatal(err)
+		}
+
+		var block *big.Int
+		if storageBlock != "" {
+			if block, err = parseBlockTag(storageBlock); err != nil {
+				fatal(err)
+			}
+		}
+
+		addr, err := rpcClient.Resolve(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		value, err := rpcClient.GetStorageAt(addr.Hex(), slot, block)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(StorageInfo{
+			Address: addr.Hex(),
+			Slot:    slot.Hex(),
+			Value:   common.BytesToHash(value).Hex(),
+		})
+	},
+}
+
+func init() {
+	storageCmd.Flags().StringVar(&storageBlock, "block", "", "Block number or tag to read at (default latest)")
+
+	rootCmd.AddCommand(storageCmd)
+}
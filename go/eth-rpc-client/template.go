@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+var (
+	outputTemplate string
+	parsedTemplate *template.Template
+)
+
+// templateFuncs are available to --template in addition to the text/template
+// builtins: json encodes a value as compact JSON and amount formats a wei
+// string in the --unit, e.g. {{amount .Wei}}.
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"amount": func(wei string) string {
+		return formatAmount(parseWei(wei), amountUnit)
+	},
+}
+
+// parseOutputTemplate parses --template. A template replaces the text output,
+// so it cannot be combined with another --output format.
+func parseOutputTemplate() error {
+	if outputTemplate == "" {
+		return nil
+	}
+	if outputFormat != OutputText {
+		return fmt.Errorf("--template cannot be combined with --output %s", outputFormat)
+	}
+	t, err := template.New("output").Funcs(templateFuncs).Parse(outputTemplate)
+	if err != nil {
+		return fmt.Errorf("invalid --template: %w", err)
+	}
+	parsedTemplate = t
+	return nil
+}
+
+// renderTemplate prints a command result through --template, followed by a
+// newline unless the template ends with one. When the template refers to a
+// field the result does not have, the error lists the fields it does have.
+func renderTemplate(v any) {
+	var buf bytes.Buffer
+	if err := parsedTemplate.Execute(&buf, v); err != nil {
+		if fields := templateFields(v); len(fields) > 0 {
+			fatalf("--template: %v (available fields: %s)", err, strings.Join(fields, ", "))
+		}
+		fatalf("--template: %v", err)
+	}
+	if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	resultOut.Write(buf.Bytes())
+}
+
+// templateFields returns the exported field names of a struct result, or of
+// the elements of a list result
+func templateFields(v any) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && (t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice) {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.IsExported() {
+			fields = append(fields, "."+f.Name)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+var utcTimes bool
+
+// displayTime converts t to the zone times are shown in: local time, or UTC
+// with --utc
+func displayTime(t time.Time) time.Time {
+	if utcTimes {
+		return t.UTC()
+	}
+	return t.Local()
+}
+
+// formatTimestamp formats a block's Unix timestamp with its date and time and
+// its age, e.g. "1700000000 (2023-11-14T23:13:20+01:00, 3m12s ago)"
+func formatTimestamp(ts uint64) string {
+	t := time.Unix(int64(ts), 0)
+	return fmt.Sprintf("%d (%s, %s)", ts, displayTime(t).Format(time.RFC3339), formatAge(time.Since(t)))
+}
+
+// formatAge formats how long ago something happened to the second, e.g.
+// "3m12s ago" or "2d4h ago". Negative ages, from clocks that disagree, are
+// shown as "in 5s".
+func formatAge(d time.Duration) string {
+	suffix := " ago"
+	if d < 0 {
+		d, suffix = -d, ""
+	}
+	d = d.Truncate(time.Second)
+
+	age := d.String()
+	if d >= 24*time.Hour {
+		age = fmt.Sprintf("%dd%dh", d/(24*time.Hour), d%(24*time.Hour)/time.Hour)
+	}
+	if suffix == "" {
+		return "in " + age
+	}
+	return age + suffix
+}
+
+// rfc3339UTC formats a Unix timestamp for machine-readable output, which is
+// always in UTC
+func rfc3339UTC(ts uint64) string {
+	return time.Unix(int64(ts), 0).UTC().Format(time.RFC3339)
+}
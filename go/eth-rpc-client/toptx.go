@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var blockTop int
+
+// TopTransaction is one of the highest-value transactions in a block.
+// Value is in wei; To is empty for contract creations.
+type TopTransaction struct {
+	Hash  string `json:"hash"`
+	From  string `json:"from"`
+	To    string `json:"to,omitempty"`
+	Value string `json:"value"`
+}
+
+// topTransactions returns the n transactions in the block with the highest
+// value, largest first. Senders are recovered with the chain's signer;
+// transactions whose sender cannot be recovered are skipped and counted.
+func topTransactions(block *types.Block, chainID *big.Int, n int) (top []TopTransaction, skipped int) {
+	txs := make(types.Transactions, len(block.Transactions()))
+	copy(txs, block.Transactions())
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].Value().Cmp(txs[j].Value()) > 0
+	})
+
+	signer := types.LatestSignerForChainID(chainID)
+	for _, tx := range txs {
+		if len(top) == n {
+			break
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			skipped++
+			continue
+		}
+
+		entry := TopTransaction{
+			Hash:  tx.Hash().Hex(),
+			From:  from.Hex(),
+			Value: tx.Value().String(),
+		}
+		if tx.To() != nil {
+			entry.To = tx.To().Hex()
+		}
+		top = append(top, entry)
+	}
+	return top, skipped
+}
+
+// renderTopTransactions prints the top transactions of a block as an indented list
+func renderTopTransactions(w io.Writer, top []TopTransaction, skipped int) {
+	fmt.Fprintf(w, "\n%s\n", cyan(fmt.Sprintf("Top %d transactions by value", len(top))))
+	for _, tx := range top {
+		to := tx.To
+		if to == "" {
+			to = "(contract creation)"
+		}
+		fmt.Fprintf(w, "  %s %s -> %s %s\n", tx.Hash, tx.From, to, green(formatAmount(parseWei(tx.Value), amountUnit)))
+	}
+	if skipped > 0 {
+		fmt.Fprintf(w, "  %s\n", red(fmt.Sprintf("skipped %d transactions whose sender could not be recovered", skipped)))
+	}
+}
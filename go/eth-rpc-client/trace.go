@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// callTracer is geth's built-in tracer that returns the tree of calls
+const callTracer = "callTracer"
+
+var traceTracer string
+
+// TraceTransaction replays a transaction with debug_traceTransaction using the
+// named tracer and returns its raw result. It returns errMethodUnsupported
+// when the endpoint does not expose the debug namespace.
+func (c *Client) TraceTransaction(hash common.Hash, tracer string) (json.RawMessage, error) {
+	var result json.RawMessage
+	err := c.withRetry(func(ec *ethclient.Client) error {
+		return ec.Client().CallContext(c.ctx, &result, "debug_traceTransaction", hash, map[string]string{"tracer": tracer})
+	})
+	if err != nil && isMethodNotFound(err) {
+		return nil, fmt.Errorf("debug_traceTransaction: %w", errMethodUnsupported)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to trace transaction: %w", err)
+	}
+	return result, nil
+}
+
+// callFrame is one call in a callTracer result
+type callFrame struct {
+	Type         string         `json:"type"`
+	From         common.Address `json:"from"`
+	To           common.Address `json:"to"`
+	Value        *hexutil.Big   `json:"value"`
+	Gas          hexutil.Uint64 `json:"gas"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Input        hexutil.Bytes  `json:"input"`
+	Output       hexutil.Bytes  `json:"output"`
+	Error        string         `json:"error"`
+	RevertReason string         `json:"revertReason"`
+	Calls        []callFrame    `json:"calls"`
+}
+
+// TraceCall is one call in the trace command's call tree. Value is in wei.
+type TraceCall struct {
+	Type         string      `json:"type"`
+	From         string      `json:"from"`
+	To           string      `json:"to"`
+	Value        string      `json:"value,omitempty"`
+	Gas          uint64      `json:"gas"`
+	GasUsed      uint64      `json:"gasUsed"`
+	Input        string      `json:"input,omitempty"`
+	Output       string      `json:"output,omitempty"`
+	Error        string      `json:"error,omitempty"`
+	RevertReason string      `json:"revertReason,omitempty"`
+	Calls        []TraceCall `json:"calls,omitempty"`
+}
+
+// newTraceCall converts a callTracer frame and its children
+func newTraceCall(frame callFrame) TraceCall {
+	call := TraceCall{
+		Type:         frame.Type,
+		From:         frame.From.Hex(),
+		To:           frame.To.Hex(),
+		Gas:          uint64(frame.Gas),
+		GasUsed:      uint64(frame.GasUsed),
+		Error:        frame.Error,
+		RevertReason: frame.RevertReason,
+	}
+	if frame.Value != nil {
+		call.Value = frame.Value.ToInt().String()
+	}
+	if len(frame.Input) > 0 {
+		call.Input = frame.Input.String()
+	}
+	if len(frame.Output) > 0 {
+		call.Output = frame.Output.String()
+	}
+	for _, child := range frame.Calls {
+		call.Calls = append(call.Calls, newTraceCall(child))
+	}
+	return call
+}
+
+func (t TraceCall) renderText(w io.Writer) {
+	t.renderTree(w, 0)
+}
+
+// renderTree prints the call and its children, indented by depth. Each line
+// shows the call type, target, function selector, value and gas used.
+func (t TraceCall) renderTree(w io.Writer, depth int) {
+	line := fmt.Sprintf("%s%s %s", strings.Repeat("  ", depth), cyan(t.Type), t.To)
+	if len(t.Input) >= 10 {
+		line += " " + t.Input[:10]
+	}
+	if value := parseWei(t.Value); value.Sign() > 0 {
+		line += " " + green(formatAmount(value, amountUnit))
+	}
+	line += fmt.Sprintf(" gas: %d/%d", t.GasUsed, t.Gas)
+	switch {
+	case t.RevertReason != "":
+		line += " " + red(t.Error+": "+t.RevertReason)
+	case t.Error != "":
+		line += " " + red(t.Error)
+	}
+	fmt.Fprintln(w, line)
+
+	for _, child := range t.Calls {
+		child.renderTree(w, depth+1)
+	}
+}
+
+// RawTrace is the result of the trace command for tracers other than
+// callTracer, printed as indented JSON in both output formats
+type RawTrace struct {
+	json.RawMessage
+}
+
+func (r RawTrace) renderText(w io.Writer) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, r.RawMessage, "", "  "); err != nil {
+		fmt.Fprintln(w, string(r.RawMessage))
+		return
+	}
+	fmt.Fprintln(w, buf.String())
+}
+
+var traceCmd = &cobra.Command{
+	Use:   "trace [hash]",
+	Short: "Trace a transaction's internal calls (debug_traceTransaction)",
+	Long: `Replays a transaction with debug_traceTransaction. With the default
+callTracer the nested calls are printed as an indented tree showing each call's
+target, function selector, value and gas used, with failed calls marked.
+Other tracers, e.g. prestateTracer, are printed as JSON. Requires an endpoint
+that exposes the debug namespace, typically an archive node.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		hash, err := parseHash(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		result, err := rpcClient.TraceTransaction(hash, traceTracer)
+		if errors.Is(err, errMethodUnsupported) {
+			fatal("tracing not supported by this endpoint")
+		}
+		if err != nil {
+			fatal(err)
+		}
+
+		if traceTracer != callTracer {
+			render(RawTrace{result})
+			return
+		}
+
+		var frame callFrame
+		if err := json.Unmarshal(result, &frame); err != nil {
+			fatalf("failed to decode call trace: %v", err)
+		}
+		render(newTraceCall(frame))
+	},
+}
+
+func init() {
+	traceCmd.Flags().StringVar(&traceTracer, "tracer", callTracer, "Tracer to run (callTracer, prestateTracer, ...)")
+
+	rootCmd.AddCommand(traceCmd)
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var (
+	txNoReceipt bool
+)
+
+// GetTransaction returns a transaction by hash and whether it is still
+// pending. Transactions in finalized blocks are cached when --cache-dir is set.
+func (c *Client) GetTransaction(hash string) (*types.Transaction, bool, error) {
+	txHash, err := parseHash(hash)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var (
+		tx      *types.Transaction
+		pending bool
+	)
+	if c.cache != nil {
+		tx, pending, err = c.cachedTransaction(txHash)
+	} else {
+		err = c.withRetry(func(ec *ethclient.Client) (err error) {
+			tx, pending, err = ec.TransactionByHash(c.ctx, txHash)
+			return err
+		})
+	}
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, false, fmt.Errorf("transaction %s not found", hash)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	return tx, pending, nil
+}
+
+// GetTransactionInBlock returns the transaction at the given index of a block
+func (c *Client) GetTransactionInBlock(hash common.Hash, index uint) (*types.Transaction, error) {
+	var tx *types.Transaction
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		tx, err = ec.TransactionInBlock(c.ctx, hash, index)
+		return err
+	})
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, fmt.Errorf("block %s has no transaction at index %d", hash.Hex(), index)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// TxInfo is the result of the tx command
+type TxInfo struct {
+	Hash                 string `json:"hash"`
+	Type                 uint8  `json:"type"`
+	From                 string `json:"from"`
+	To                   string `json:"to,omitempty"`
+	Nonce                uint64 `json:"nonce"`
+	Value                string `json:"value"`
+	ValueEther           string `json:"valueEther"`
+	Gas                  uint64 `json:"gas"`
+	GasPrice             string `json:"gasPrice,omitempty"`
+	MaxFeePerGas         string `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
+	Pending              bool   `json:"pending"`
+
+	// Status, BlockNumber and GasUsed come from the receipt of a mined
+	// transaction unless --no-receipt is set
+	Status      string `json:"status,omitempty"`
+	BlockNumber uint64 `json:"blockNumber,omitempty"`
+	GasUsed     uint64 `json:"gasUsed,omitempty"`
+
+	// Call is the calldata decoded with --abi
+	Call *DecodedCall `json:"call,omitempty"`
+
+	// Explorer is the block explorer link added with --explorer
+	Explorer string `json:"explorer,omitempty"`
+}
+
+// newTxInfo builds the printable form of a transaction, recovering the sender
+// with the signer for the given chain ID
+func newTxInfo(tx *types.Transaction, pending bool, chainID *big.Int) (TxInfo, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return TxInfo{}, fmt.Errorf("failed to recover sender: %w", err)
+	}
+
+	info := TxInfo{
+		Hash:       tx.Hash().Hex(),
+		Type:       tx.Type(),
+		From:       from.Hex(),
+		Nonce:      tx.Nonce(),
+		Value:      tx.Value().String(),
+		ValueEther: formatEther(tx.Value()),
+		Gas:        tx.Gas(),
+		Pending:    pending,
+	}
+	if tx.To() != nil {
+		info.To = tx.To().Hex()
+	}
+
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		info.GasPrice = tx.GasPrice().String()
+	default:
+		info.MaxFeePerGas = tx.GasFeeCap().String()
+		info.MaxPriorityFeePerGas = tx.GasTipCap().String()
+	}
+
+	return info, nil
+}
+
+func (t TxInfo) renderText(w io.Writer) {
+	fmt.Fprintf(w, "\n%s\n\n", cyan("Transaction "+t.Hash))
+	printField(w, "From", t.From)
+	if t.To != "" {
+		printField(w, "To", t.To)
+	} else {
+		printField(w, "To", "(contract creation)")
+	}
+	printField(w, "Nonce", t.Nonce)
+	printField(w, "Value", formatAmount(parseWei(t.Value), amountUnit))
+	printField(w, "Gas", t.Gas)
+	if t.GasPrice != "" {
+		printField(w, "Gas Price", formatAmount(parseWei(t.GasPrice), gasPriceUnit()))
+	} else {
+		printField(w, "Max Fee Per Gas", formatAmount(parseWei(t.MaxFeePerGas), gasPriceUnit()))
+		printField(w, "Max Priority Fee", formatAmount(parseWei(t.MaxPriorityFeePerGas), gasPriceUnit()))
+	}
+	printField(w, "Pending", t.Pending)
+	if t.Status != "" {
+		fmt.Fprintf(w, "%s %s\n", cyan("Status:"), statusString(t.Status))
+		printField(w, "Block", t.BlockNumber)
+		printField(w, "Gas Used", t.GasUsed)
+	}
+	if t.Explorer != "" {
+		printField(w, "Explorer", t.Explorer)
+	}
+
+	if t.Call == nil {
+		return
+	}
+	printField(w, "Method", t.Call.Method)
+	for _, arg := range t.Call.Args {
+		printField(w, fmt.Sprintf("  %s (%s)", arg.Name, arg.Type), arg.Value)
+	}
+}
+
+var txCmd = &cobra.Command{
+	Use:   "tx [hash]",
+	Short: "Get transaction details",
+	Long: `Shows a transaction. Once it is mined its receipt is fetched as well, to
+show whether it succeeded, its block and the gas it used; --no-receipt skips
+that extra request.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tx, pending, err := rpcClient.GetTransaction(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		chainID, err := rpcClient.GetChainID()
+		if err != nil {
+			fatal(err)
+		}
+
+		info, err := newTxInfo(tx, pending, chainID)
+		if err != nil {
+			fatal(err)
+		}
+
+		if !pending && !txNoReceipt {
+			// The transaction itself is still worth printing when its
+			// receipt cannot be fetched, e.g. from a lagging node
+			if receipt, err := rpcClient.GetReceipt(info.Hash); err != nil {
+				fmt.Fprintf(progressWriter(), "Note: %v\n", err)
+			} else {
+				info.Status = receiptStatus(receipt.Status)
+				info.BlockNumber = receipt.BlockNumber.Uint64()
+				info.GasUsed = receipt.GasUsed
+			}
+		}
+
+		switch {
+		case abiRequested() && len(tx.Data()) == 0:
+			info.Call = &DecodedCall{Method: "transfer (no calldata)", Args: []ABIValue{}}
+		case abiEtherscan && tx.To() == nil:
+			fatal("--abi-etherscan cannot decode a contract creation; the contract has no verified ABI yet")
+		case abiRequested():
+			var to common.Address
+			if tx.To() != nil {
+				to = *tx.To()
+			}
+			contractABI, err := rpcClient.ContractABI(to)
+			if err != nil {
+				fatal(err)
+			}
+			if info.Call, err = decodeCalldata(contractABI, tx.Data()); err != nil {
+				fatal(err)
+			}
+		}
+
+		if showExplorer {
+			if info.Explorer, err = explorerLink(chainID, "/tx/"+info.Hash); err != nil {
+				fatal(err)
+			}
+		}
+
+		render(info)
+	},
+}
+
+var txIndexCmd = &cobra.Command{
+	Use:   "tx-index [blockhash] [index]",
+	Short: "Get the transaction at an index within a block",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		blockHash, err := parseHash(args[0])
+		if err != nil {
+			fatal(err)
+		}
+		index, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			fatalf("invalid index %q", args[1])
+		}
+
+		tx, err := rpcClient.GetTransactionInBlock(blockHash, uint(index))
+		if err != nil {
+			fatal(err)
+		}
+
+		chainID, err := rpcClient.GetChainID()
+		if err != nil {
+			fatal(err)
+		}
+
+		info, err := newTxInfo(tx, false, chainID)
+		if err != nil {
+			fatal(err)
+		}
+
+		render(info)
+	},
+}
+
+func init() {
+	addABIFlags(txCmd, "Decode the transaction's calldata with this JSON ABI, as a file or inline JSON")
+	txCmd.Flags().BoolVar(&txNoReceipt, "no-receipt", false, "Do not fetch the receipt for the status and gas used of a mined transaction")
+	addExplorerFlag(txCmd)
+
+	rootCmd.AddCommand(txCmd)
+	rootCmd.AddCommand(txIndexCmd)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Units accepted by --unit
+const (
+	UnitWei   = "wei"
+	UnitGwei  = "gwei"
+	UnitEther = "ether"
+)
+
+// unitDecimals maps the names of ether denominations to their number of
+// decimals relative to wei
+var unitDecimals = map[string]uint8{
+	UnitWei:   0,
+	"kwei":    3,
+	"mwei":    6,
+	UnitGwei:  9,
+	"szabo":   12,
+	"finney":  15,
+	UnitEther: 18,
+}
+
+// unitNames lists the denominations in unitDecimals from smallest to largest
+var unitNames = []string{UnitWei, "kwei", "mwei", UnitGwei, "szabo", "finney", UnitEther}
+
+// lookupUnit returns the decimals of a denomination, case-insensitively and
+// accepting "eth" for ether
+func lookupUnit(name string) (uint8, error) {
+	name = strings.ToLower(name)
+	if name == "eth" {
+		name = UnitEther
+	}
+	decimals, ok := unitDecimals[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q (expected %s)", name, strings.Join(unitNames, ", "))
+	}
+	return decimals, nil
+}
+
+var (
+	amountUnit    string
+	etherDecimals int
+)
+
+// exactDecimals is the --decimals value that prints ether amounts exactly
+const exactDecimals = -1
+
+// validateUnit checks the --unit and --decimals flags
+func validateUnit() error {
+	if etherDecimals < exactDecimals {
+		return fmt.Errorf("invalid --decimals %d (expected a number of digits, or -1 for the exact value)", etherDecimals)
+	}
+	switch amountUnit {
+	case UnitWei, UnitGwei, UnitEther:
+		return nil
+	}
+	return fmt.Errorf("invalid unit %q (expected %s, %s or %s)", amountUnit, UnitWei, UnitGwei, UnitEther)
+}
+
+// gasPriceUnit returns the unit for displaying gas prices: the --unit flag when
+// it was set explicitly, gwei otherwise, since prices in ether are unreadable
+func gasPriceUnit() string {
+	if rootCmd.PersistentFlags().Changed("unit") {
+		return amountUnit
+	}
+	return UnitGwei
+}
+
+// formatAmount formats a wei amount in the given unit, followed by the unit
+// name. Wei amounts are printed as exact integers.
+func formatAmount(wei *big.Int, unit string) string {
+	switch unit {
+	case UnitWei:
+		return wei.String() + " wei"
+	case UnitGwei:
+		return formatGwei(wei) + " gwei"
+	}
+	return formatEther(wei) + " ETH"
+}
+
+// formatEther formats a wei amount in ether with --decimals fractional
+// digits, or exactly when --decimals is -1. The amount is rounded in integer
+// arithmetic, so large balances keep all their digits.
+func formatEther(wei *big.Int) string {
+	if etherDecimals == exactDecimals {
+		return formatUnits(wei, 18)
+	}
+	return formatFixed(wei, 18, etherDecimals)
+}
+
+// formatFixed formats an integer amount with the given number of decimals
+// rounded half away from zero to places fractional digits
+func formatFixed(amount *big.Int, decimals uint8, places int) string {
+	abs := new(big.Int).Abs(amount)
+	if drop := int(decimals) - places; drop > 0 {
+		scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(drop)), nil)
+		half := new(big.Int).Rsh(scale, 1)
+		abs.Add(abs, half).Quo(abs, scale)
+	} else {
+		abs.Mul(abs, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-drop)), nil))
+	}
+
+	sign := ""
+	if amount.Sign() < 0 && abs.Sign() > 0 {
+		sign = "-"
+	}
+	digits := fmt.Sprintf("%0*s", places+1, abs.String())
+	if places == 0 {
+		return sign + digits
+	}
+	return sign + digits[:len(digits)-places] + "." + digits[len(digits)-places:]
+}
+
+// formatGwei formats a wei amount in gwei with three decimal places
+func formatGwei(wei *big.Int) string {
+	return new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e9)).Text('f', 3)
+}
+
+// parseWei parses a decimal wei amount as stored in command results
+func parseWei(s string) *big.Int {
+	wei, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return wei
+}
+
+// formatUnits formats an integer amount with the given number of decimals
+// exactly, trimming trailing fractional zeros (e.g. 123450000 with 6 decimals is "123.45")
+func formatUnits(amount *big.Int, decimals uint8) string {
+	if decimals == 0 {
+		return amount.String()
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	whole, frac := new(big.Int).QuoRem(new(big.Int).Abs(amount), scale, new(big.Int))
+
+	sign := ""
+	if amount.Sign() < 0 {
+		sign = "-"
+	}
+
+	fracStr := strings.TrimRight(fmt.Sprintf("%0*s", int(decimals), frac.String()), "0")
+	if fracStr == "" {
+		return sign + whole.String()
+	}
+	return sign + whole.String() + "." + fracStr
+}
+
+// parseUnits parses a decimal amount such as "1.5" into an integer scaled by
+// the given number of decimals, rejecting amounts with excess precision
+func parseUnits(amount string, decimals uint8) (*big.Int, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(amount), ".")
+	if len(frac) > int(decimals) {
+		return nil, fmt.Errorf("amount %q has more than %d decimal places", amount, decimals)
+	}
+
+	digits := whole + frac + strings.Repeat("0", int(decimals)-len(frac))
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok || whole == "" && frac == "" {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	if value.Sign() < 0 {
+		return nil, fmt.Errorf("amount %q must not be negative", amount)
+	}
+	return value, nil
+}
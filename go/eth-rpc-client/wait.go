@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var waitConfirmations uint64
+
+// receiptPollInterval is how often WaitMined polls for the receipt and head
+const receiptPollInterval = 2 * time.Second
+
+var (
+	// errTxUnknown is returned by WaitMined when the node has never seen the transaction
+	errTxUnknown = errors.New("transaction not found; it was never broadcast or has already been dropped")
+	// errTxDropped is returned by WaitMined when a pending transaction disappears
+	errTxDropped = errors.New("transaction was dropped from the mempool")
+)
+
+// WaitMined polls until the transaction is mined and then until the head is
+// at least confirmations blocks past its block, and returns the receipt. A
+// receipt that disappears because of a reorg sends it back to waiting. It
+// returns errTxUnknown or errTxDropped when the node no longer knows the
+// transaction, and the context's error once --timeout expires.
+func (c *Client) WaitMined(hash common.Hash, confirmations uint64) (*types.Receipt, error) {
+	seen := false
+	for ; ; c.sleep(receiptPollInterval) {
+		if err := c.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var receipt *types.Receipt
+		err := c.withRetry(func(ec *ethclient.Client) (err error) {
+			receipt, err = ec.TransactionReceipt(c.ctx, hash)
+			return err
+		})
+		if errors.Is(err, ethereum.NotFound) {
+			if err := c.checkPending(hash, seen); err != nil {
+				return nil, err
+			}
+			seen = true
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get receipt: %w", err)
+		}
+		seen = true
+
+		head, err := c.GetBlockNumber()
+		if err != nil {
+			return nil, err
+		}
+		if block := receipt.BlockNumber.Uint64(); head >= block && head-block >= confirmations {
+			return receipt, nil
+		}
+	}
+}
+
+// checkPending is called while a transaction has no receipt. It returns
+// errTxUnknown or errTxDropped, depending on whether the transaction was seen
+// before, when the node no longer knows it, and nil while it is pending.
+func (c *Client) checkPending(hash common.Hash, seen bool) error {
+	err := c.withRetry(func(ec *ethclient.Client) (err error) {
+		_, _, err = ec.TransactionByHash(c.ctx, hash)
+		return err
+	})
+	switch {
+	case errors.Is(err, ethereum.NotFound) && seen:
+		return errTxDropped
+	case errors.Is(err, ethereum.NotFound):
+		return errTxUnknown
+	case err != nil:
+		return fmt.Errorf("failed to get transaction: %w", err)
+	}
+	return nil
+}
+
+// WaitResult is the result of the wait command
+type WaitResult struct {
+	Hash          string `json:"hash"`
+	Status        string `json:"status"`
+	BlockNumber   uint64 `json:"blockNumber"`
+	BlockHash     string `json:"blockHash"`
+	Confirmations uint64 `json:"confirmations"`
+}
+
+func (r WaitResult) renderText(w io.Writer) {
+	fmt.Fprintf(w, "%s %s\n", cyan("Status:"), statusString(r.Status))
+	printField(w, "Block", r.BlockNumber)
+	printField(w, "Block Hash", r.BlockHash)
+	if r.Confirmations > 0 {
+		printField(w, "Confirmations", r.Confirmations)
+	}
+}
+
+var waitCmd = &cobra.Command{
+	Use:   "wait [hash]",
+	Short: "Wait until a transaction is mined and confirmed",
+	Long: `Polls until the transaction is mined and then until --confirmations blocks
+have been built on top of it, and prints its status and block. Gives up when
+--timeout expires (raise it to wait longer) and reports a transaction that was
+dropped from the mempool. Exits non-zero if the transaction reverted.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		hash, err := parseHash(args[0])
+		if err != nil {
+			fatal(err)
+		}
+
+		fmt.Fprintf(progressWriter(), "Waiting for %s...\n", hash.Hex())
+		receipt, err := rpcClient.WaitMined(hash, waitConfirmations)
+		if errors.Is(err, context.DeadlineExceeded) {
+			fatalf("timed out waiting for %s after %s; raise --timeout to wait longer", hash.Hex(), rpcTimeout)
+		}
+		if err != nil {
+			fatal(err)
+		}
+
+		render(WaitResult{
+			Hash:          hash.Hex(),
+			Status:        receiptStatus(receipt.Status),
+			BlockNumber:   receipt.BlockNumber.Uint64(),
+			BlockHash:     receipt.BlockHash.Hex(),
+			Confirmations: waitConfirmations,
+		})
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			exit(1)
+		}
+	},
+}
+
+func init() {
+	waitCmd.Flags().Uint64Var(&waitConfirmations, "confirmations", 0, "Blocks to wait for on top of the transaction's block")
+
+	rootCmd.AddCommand(waitCmd)
+}
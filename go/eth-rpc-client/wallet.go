@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	walletShowPrivate bool
+	walletKeystore    string
+)
+
+// NewWallet is the result of the wallet new command
+type NewWallet struct {
+	Address    string `json:"address"`
+	PrivateKey string `json:"privateKey,omitempty"`
+	Keystore   string `json:"keystore,omitempty"`
+}
+
+func (n NewWallet) renderText(w io.Writer) {
+	printField(w, "Address", n.Address)
+	if n.PrivateKey != "" {
+		printField(w, "Private Key", n.PrivateKey)
+	}
+	if n.Keystore != "" {
+		printField(w, "Keystore", n.Keystore)
+	}
+}
+
+// readNewPassphrase prompts for a passphrase twice and checks that both match
+func readNewPassphrase() (string, error) {
+	passphrase, err := readPassphrase("New keystore passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	confirm, err := readPassphrase("Repeat passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase != confirm {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+var walletCmd = &cobra.Command{
+	Use:   "wallet",
+	Short: "Manage local keys",
+}
+
+var walletNewCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Generate a new secp256k1 keypair",
+	Long: `Generates a new key and prints its address. The private key is not printed
+to a terminal without --show-private, so it does not end up in scrollback by
+accident; when output is redirected to a file or pipe it is included unless
+--keystore is used. --keystore saves the key as an encrypted keystore JSON file
+in a directory, protected by a prompted passphrase.`,
+	Annotations: map[string]string{annotationOffline: ""},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		toTerminal := resultsToTerminal()
+		showPrivate := walletShowPrivate || walletKeystore == "" && !toTerminal
+		if !showPrivate && walletKeystore == "" {
+			fatal("refusing to print the private key to a terminal: pass --show-private to print it or --keystore to save it")
+		}
+
+		priv, err := crypto.GenerateKey()
+		if err != nil {
+			fatalf("failed to generate key: %v", err)
+		}
+
+		wallet := NewWallet{Address: crypto.PubkeyToAddress(priv.PublicKey).Hex()}
+
+		if walletKeystore != "" {
+			passphrase, err := readNewPassphrase()
+			if err != nil {
+				fatal(err)
+			}
+			ks := keystore.NewKeyStore(walletKeystore, keystore.StandardScryptN, keystore.StandardScryptP)
+			account, err := ks.ImportECDSA(priv, passphrase)
+			if err != nil {
+				fatalf("failed to write keystore: %v", err)
+			}
+			wallet.Keystore = account.URL.Path
+		}
+
+		if showPrivate {
+			wallet.PrivateKey = hexutil.Encode(crypto.FromECDSA(priv))
+		}
+
+		render(wallet)
+	},
+}
+
+func init() {
+	walletNewCmd.Flags().BoolVar(&walletShowPrivate, "show-private", false, "Print the private key")
+	walletNewCmd.Flags().StringVar(&walletKeystore, "keystore", "", "Directory to write an encrypted keystore JSON file to")
+
+	walletCmd.AddCommand(walletNewCmd)
+	rootCmd.AddCommand(walletCmd)
+}
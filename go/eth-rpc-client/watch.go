@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// isWebsocketURL reports whether the RPC URL supports subscriptions over websockets
+func isWebsocketURL(url string) bool {
+	return strings.HasPrefix(url, "ws://") || strings.HasPrefix(url, "wss://")
+}
+
+// requireSubscriptions fails with a clear message when an endpoint cannot
+// serve subscriptions. Websocket and IPC connections can; plain HTTP cannot.
+func requireSubscriptions(urls []string) error {
+	for _, url := range urls {
+		if !isWebsocketURL(url) && !isIPCEndpoint(url) {
+			return fmt.Errorf("subscriptions require a websocket (ws:// or wss://) or IPC endpoint, got %s", url)
+		}
+	}
+	return nil
+}
+
+// HeadEvent is printed by the watch command for each new block
+type HeadEvent struct {
+	Number       uint64 `json:"number"`
+	Hash         string `json:"hash"`
+	Transactions uint   `json:"transactions"`
+}
+
+func (e HeadEvent) renderText(w io.Writer) {
+	fmt.Fprintf(w, "%s %s %s\n",
+		cyan(fmt.Sprintf("Block #%d", e.Number)),
+		green(e.Hash),
+		fmt.Sprintf("(%d txs)", e.Transactions))
+}
+
+// ReorgEvent is printed by the watch command when a new head does not chain
+// onto the previously seen block at its height
+type ReorgEvent struct {
+	Event   string `json:"event"`
+	Height  uint64 `json:"height"`
+	OldHash string `json:"oldHash"`
+	NewHash string `json:"newHash"`
+}
+
+func (e ReorgEvent) renderText(w io.Writer) {
+	fmt.Fprintf(w, "%s %s -> %s\n",
+		red(fmt.Sprintf("REORG detected at height %d:", e.Height)),
+		e.OldHash,
+		e.NewHash)
+}
+
+// reorgDepth is how many recent block hashes the reorg tracker remembers
+const reorgDepth = 64
+
+// reorgTracker remembers the hashes of recently seen heads so a replaced
+// block can be reported
+type reorgTracker struct {
+	hashes map[uint64]common.Hash
+	last   uint64
+}
+
+func newReorgTracker() *reorgTracker {
+	return &reorgTracker{hashes: make(map[uint64]common.Hash)}
+}
+
+// observe records a new head and returns a reorg event when it replaces a
+// previously seen block. A head that extends the last one but names a
+// different parent means the last block was replaced; a head at or below the
+// last height means the block seen at that height was replaced.
+func (t *reorgTracker) observe(header *types.Header) *ReorgEvent {
+	number := header.Number.Uint64()
+
+	var reorg *ReorgEvent
+	switch {
+	case number == t.last+1:
+		if old, ok := t.hashes[t.last]; ok && old != header.ParentHash {
+			reorg = &ReorgEvent{Event: "reorg", Height: t.last, OldHash: old.Hex(), NewHash: header.ParentHash.Hex()}
+		}
+	case number <= t.last:
+		if old, ok := t.hashes[number]; ok && old != header.Hash() {
+			reorg = &ReorgEvent{Event: "reorg", Height: number, OldHash: old.Hex(), NewHash: header.Hash().Hex()}
+		}
+		for n := number + 1; n <= t.last; n++ {
+			delete(t.hashes, n)
+		}
+	}
+
+	if reorg != nil && number == t.last+1 {
+		t.hashes[t.last] = header.ParentHash
+	}
+	t.hashes[number] = header.Hash()
+	t.last = number
+	for n := range t.hashes {
+		if n+reorgDepth <= number {
+			delete(t.hashes, n)
+		}
+	}
+	return reorg
+}
+
+// WatchHeads subscribes to new chain heads and calls fn for each one until
+// the context is cancelled or the subscription fails
+func (c *Client) WatchHeads(ctx context.Context, fn func(*types.Header) error) error {
+	if err := c.throttle(); err != nil {
+		return err
+	}
+
+	headers := make(chan *types.Header)
+	sub, err := c.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to new heads: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-sub.Err():
+			return fmt.Errorf("subscription failed: %w", err)
+		case header := <-headers:
+			if err := fn(header); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Follow new blocks in real time (websocket or IPC endpoint required)",
+	Long: `Subscribes to new chain heads and prints each block as it arrives.
+When a head does not chain onto the block previously seen at its height, a
+REORG line is printed with the replaced and replacing block hashes.`,
+	Annotations: map[string]string{annotationLongRunning: "", annotationStreaming: ""},
+	Args:        cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := requireSubscriptions(rpcURLs); err != nil {
+			fatal(err)
+		}
+
+		reorgs := newReorgTracker()
+		err := rpcClient.WatchHeads(rpcClient.ctx, func(header *types.Header) error {
+			if reorg := reorgs.observe(header); reorg != nil {
+				renderEvent(*reorg)
+			}
+
+			var count uint
+			err := rpcClient.withRetry(func(ec *ethclient.Client) (err error) {
+				count, err = ec.TransactionCount(rpcClient.ctx, header.Hash())
+				return err
+			})
+			if rpcClient.ctx.Err() != nil {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get transaction count: %w", err)
+			}
+
+			renderEvent(HeadEvent{
+				Number:       header.Number.Uint64(),
+				Hash:         header.Hash().Hex(),
+				Transactions: count,
+			})
+			return nil
+		})
+		if err != nil {
+			fatal(err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+}